@@ -0,0 +1,233 @@
+package looker
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+)
+
+// The SDK is generated from the 3.0 swagger spec. The 4.0 API serves the same operations with two differences
+// the provider cares about: spaces are called folders, in paths and in fields, and IDs are sent and returned as
+// strings. This file translates between the two so the 3.0 operations can be submitted to a 4.0 endpoint. IDs of
+// objects must still be numeric.
+
+// api40RenamedFields are the fields of the SDK models 4.0 renamed, by their 4.0 name
+var api40RenamedFields = map[string]string{
+	"folder":                "space",
+	"folder_id":             "space_id",
+	"home_folder_id":        "home_space_id",
+	"personal_folder_id":    "personal_space_id",
+	"embed_group_folder_id": "embed_group_space_id",
+}
+
+// api30RenamedFields are the same fields by their 3.0 name
+var api30RenamedFields = map[string]string{}
+
+func init() {
+	for name40, name30 := range api40RenamedFields {
+		api30RenamedFields[name30] = name40
+	}
+}
+
+// api40Transport submits 3.0 operations to the 4.0 API
+type api40Transport struct {
+	transport runtime.ClientTransport
+}
+
+// newAPI40Transport configures rt to exchange 4.0 JSON and returns a transport rewriting 3.0 paths for it
+func newAPI40Transport(rt *httptransport.Runtime) runtime.ClientTransport {
+	rt.Consumers[runtime.JSONMime] = api40JSONConsumer()
+	rt.Producers[runtime.JSONMime] = api40JSONProducer()
+
+	return &api40Transport{transport: rt}
+}
+
+// Submit implements runtime.ClientTransport
+func (t *api40Transport) Submit(operation *runtime.ClientOperation) (interface{}, error) {
+	op := *operation
+	if op.PathPattern == "/spaces" || strings.HasPrefix(op.PathPattern, "/spaces/") {
+		op.PathPattern = "/folders" + strings.TrimPrefix(op.PathPattern, "/spaces")
+	}
+
+	return t.transport.Submit(&op)
+}
+
+// api40JSONConsumer decodes 4.0 responses, giving renamed fields their 3.0 names and turning string IDs into numbers
+// wherever the SDK model expects one
+func api40JSONConsumer() runtime.Consumer {
+	return runtime.ConsumerFunc(func(reader io.Reader, data interface{}) error {
+		dec := json.NewDecoder(reader)
+		dec.UseNumber()
+
+		var value interface{}
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+
+		value = renameFields(value, api40RenamedFields)
+
+		b, err := json.Marshal(numericIDs(value, reflect.TypeOf(data)))
+		if err != nil {
+			return err
+		}
+
+		// numbers decoded into interface{} values stay json.Number, as with the 3.0 consumer
+		dec = json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		return dec.Decode(data)
+	})
+}
+
+// api40JSONProducer encodes request bodies, giving renamed fields their 4.0 names and sending IDs as strings
+func api40JSONProducer() runtime.Producer {
+	return runtime.ProducerFunc(func(writer io.Writer, data interface{}) error {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+
+		var value interface{}
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+
+		// a bare list in a request body is either a list of IDs, e.g. the groups of a role, or a list of objects, e.g.
+		// the group values of a user attribute
+		if list, ok := value.([]interface{}); ok {
+			for i, item := range list {
+				if _, ok := item.(map[string]interface{}); ok {
+					list[i] = stringIDFields(renameFields(item, api30RenamedFields))
+				}
+			}
+			value = stringIDs(list)
+		} else {
+			value = stringIDFields(renameFields(value, api30RenamedFields))
+		}
+
+		return json.NewEncoder(writer).Encode(value)
+	})
+}
+
+// numericIDs walks a decoded JSON value alongside the Go type it will be decoded into, replacing strings with
+// numbers where the type holds an integer
+func numericIDs(value interface{}, t reflect.Type) interface{} {
+	if t == nil {
+		return value
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if field.Anonymous && name == "" {
+				numericIDs(object, field.Type)
+				continue
+			}
+			if v, ok := object[name]; ok {
+				object[name] = numericIDs(v, field.Type)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		list, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+		for i := range list {
+			list[i] = numericIDs(list[i], t.Elem())
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		for k, v := range object {
+			object[k] = numericIDs(v, t.Elem())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if s, ok := value.(string); ok {
+			if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return json.Number(s)
+			}
+		}
+	}
+
+	return value
+}
+
+// renameFields renames the fields of every object in a decoded JSON value, unless an object already has a field by
+// the new name
+func renameFields(value interface{}, names map[string]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			v[key] = renameFields(field, names)
+		}
+		for from, to := range names {
+			field, ok := v[from]
+			if _, taken := v[to]; !ok || taken {
+				continue
+			}
+			v[to] = field
+			delete(v, from)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = renameFields(v[i], names)
+		}
+	}
+
+	return value
+}
+
+// stringIDFields replaces numbers with strings in every "id", "*_id" and "*_ids" field of a decoded JSON value
+func stringIDFields(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			switch {
+			case key == "id" || strings.HasSuffix(key, "_id"):
+				if n, ok := field.(json.Number); ok {
+					v[key] = n.String()
+				}
+			case strings.HasSuffix(key, "_ids"):
+				if list, ok := field.([]interface{}); ok {
+					v[key] = stringIDs(list)
+				}
+			default:
+				v[key] = stringIDFields(field)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = stringIDFields(v[i])
+		}
+	}
+
+	return value
+}
+
+func stringIDs(list []interface{}) []interface{} {
+	for i, item := range list {
+		if n, ok := item.(json.Number); ok {
+			list[i] = n.String()
+		}
+	}
+	return list
+}
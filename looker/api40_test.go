@@ -0,0 +1,138 @@
+package looker
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/go-openapi/runtime"
+)
+
+func TestAPI40JSONConsumer(t *testing.T) {
+	consumer := api40JSONConsumer()
+
+	user := &lookerUser{}
+	err := consumer.Consume(strings.NewReader(`{
+		"id": "12",
+		"first_name": "Jane",
+		"home_folder_id": "34",
+		"personal_folder_id": "56",
+		"role_ids": ["1", "2"],
+		"ui_state": {"homepageGroupIdPreference": "7"}
+	}`), user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != 12 || user.FirstName != "Jane" || user.HomeSpaceID != "34" || user.PersonalSpaceID != 56 {
+		t.Errorf("unexpected user %+v", user.User)
+	}
+	if !reflect.DeepEqual(user.RoleIds, []int64{1, 2}) {
+		t.Errorf("got role_ids %v, want [1 2]", user.RoleIds)
+	}
+	if user.UIState["homepageGroupIdPreference"] != "7" {
+		t.Errorf("got ui_state %v", user.UIState)
+	}
+
+	dashboard := &models.Dashboard{}
+	err = consumer.Consume(strings.NewReader(`{"id": "3", "folder_id": "8", "folder": {"id": "8", "parent_id": "1", "name": "Reports"}}`), dashboard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dashboard.SpaceID != 8 || dashboard.Space == nil || dashboard.Space.Name != "Reports" || *dashboard.Space.ParentID != 1 {
+		t.Errorf("unexpected dashboard %+v", dashboard)
+	}
+
+	config := authConfig{}
+	err = consumer.Consume(strings.NewReader(`{"default_new_user_role_ids": [1000000], "allowed_clock_drift": 30}`), &config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := getStringsFromJSON(config["default_new_user_role_ids"]); !reflect.DeepEqual(got, []string{"1000000"}) {
+		t.Errorf("got default_new_user_role_ids %v, want [1000000]", got)
+	}
+	if _, ok := config["allowed_clock_drift"].(json.Number); !ok {
+		t.Errorf("expected numbers to be decoded as json.Number, got %T", config["allowed_clock_drift"])
+	}
+}
+
+func TestAPI40JSONProducer(t *testing.T) {
+	producer := api40JSONProducer()
+
+	tests := []struct {
+		name string
+		body interface{}
+		want string
+	}{
+		{"renamed fields", &userBody{FirstName: "Jane", HomeSpaceID: "34"}, `{"first_name": "Jane", "home_folder_id": "34"}`},
+		{"nested ids", &models.ContentMetaGroupUser{ContentMetadataID: 5, GroupID: 6, PermissionType: "view"}, `{"content_metadata_id": "5", "group_id": "6", "permission_type": "view"}`},
+		{"id lists", map[string]interface{}{"space_id": 8, "role_ids": []int64{1, 2}}, `{"folder_id": "8", "role_ids": ["1", "2"]}`},
+		{"bare id list", []int64{1, 2}, `["1", "2"]`},
+		{
+			"object list",
+			[]*models.UserAttributeGroupValue{{GroupID: 5, UserAttributeID: 7, Value: "sales"}, {GroupID: 6, UserAttributeID: 7, Value: "it"}},
+			`[{"group_id": "5", "user_attribute_id": "7", "value": "sales"}, {"group_id": "6", "user_attribute_id": "7", "value": "it"}]`,
+		},
+		{"renamed fields in object list", []map[string]interface{}{{"space_id": 8}}, `[{"folder_id": "8"}]`},
+		{"taken name", map[string]interface{}{"space_id": 8, "folder_id": 9}, `{"space_id": "8", "folder_id": "9"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			if err := producer.Produce(buf, test.body); err != nil {
+				t.Fatal(err)
+			}
+
+			var got, want interface{}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(test.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %s, want %s", buf.String(), test.want)
+			}
+		})
+	}
+}
+
+// recordingTransport records the operations it's given
+type recordingTransport struct {
+	operations []*runtime.ClientOperation
+}
+
+func (t *recordingTransport) Submit(operation *runtime.ClientOperation) (interface{}, error) {
+	t.operations = append(t.operations, operation)
+	return nil, nil
+}
+
+func TestAPI40TransportPaths(t *testing.T) {
+	recorder := &recordingTransport{}
+	transport := &api40Transport{transport: recorder}
+
+	paths := map[string]string{
+		"/spaces":                 "/folders",
+		"/spaces/{space_id}":      "/folders/{space_id}",
+		"/spaces/search":          "/folders/search",
+		"/users/{user_id}":        "/users/{user_id}",
+		"/spaces_of_other_things": "/spaces_of_other_things",
+	}
+	for path, want := range paths {
+		operation := &runtime.ClientOperation{PathPattern: path}
+		if _, err := transport.Submit(operation); err != nil {
+			t.Fatal(err)
+		}
+
+		got := recorder.operations[len(recorder.operations)-1].PathPattern
+		if got != want {
+			t.Errorf("%s was submitted to %s, want %s", path, got, want)
+		}
+		if operation.PathPattern != path {
+			t.Errorf("the operation for %s was changed", path)
+		}
+	}
+}
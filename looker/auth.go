@@ -0,0 +1,177 @@
+package looker
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/billtrust/looker-go-sdk/client/api_auth"
+	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// tokenRefreshMargin is how long before the token's expires_in runs out that a new token is requested
+const tokenRefreshMargin = 5 * time.Minute
+
+// authTransport logs in to the Looker API with the provider credentials and adds the access token to every
+// operation. The token is refreshed before it expires, and an operation rejected with a 401 is replayed once
+// with a fresh login.
+type authTransport struct {
+	transport    runtime.ClientTransport
+	clientID     string
+	clientSecret string
+
+	// afterLogin, if set, is called with a transport using every new access token
+	afterLogin func(transport runtime.ClientTransport) error
+
+	mu        sync.Mutex
+	token     string
+	refreshAt time.Time
+}
+
+// activeAuthTransports holds every authTransport created by this process so their tokens can be revoked on shutdown
+var activeAuthTransports struct {
+	sync.Mutex
+	list []*authTransport
+}
+
+func newAuthTransport(transport runtime.ClientTransport, clientID string, clientSecret string) *authTransport {
+	t := &authTransport{
+		transport:    transport,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+	}
+
+	activeAuthTransports.Lock()
+	activeAuthTransports.list = append(activeAuthTransports.list, t)
+	activeAuthTransports.Unlock()
+
+	return t
+}
+
+// Submit implements runtime.ClientTransport
+func (t *authTransport) Submit(operation *runtime.ClientOperation) (interface{}, error) {
+	token, err := t.accessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := withToken(t.transport, token).Submit(operation)
+	if apiErrorStatus(err) != http.StatusUnauthorized {
+		return result, err
+	}
+
+	log.Printf("[DEBUG] %s %s was rejected as unauthorized, logging in again", operation.Method, operation.PathPattern)
+
+	t.invalidate(token)
+
+	token, err = t.accessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return withToken(t.transport, token).Submit(operation)
+}
+
+// accessToken returns the current access token, logging in first when there is none or it is about to expire
+func (t *authTransport) accessToken() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Now().Before(t.refreshAt) {
+		return t.token, nil
+	}
+
+	params := api_auth.NewLoginParams()
+	params.ClientID = &t.clientID
+	params.ClientSecret = &t.clientSecret
+
+	resp, err := api_auth.New(t.transport, strfmt.Default).Login(params)
+	if err != nil {
+		return "", err
+	}
+
+	ttl := time.Duration(resp.Payload.ExpiresIn) * time.Second
+	margin := tokenRefreshMargin
+	if ttl < 2*margin {
+		margin = ttl / 2
+	}
+
+	t.token = resp.Payload.AccessToken
+	t.refreshAt = time.Now().Add(ttl - margin)
+
+	log.Printf("[DEBUG] Logged in to the Looker API, token expires in %s", ttl)
+
+	if t.afterLogin != nil {
+		if err := t.afterLogin(withToken(t.transport, t.token)); err != nil {
+			return "", err
+		}
+	}
+
+	return t.token, nil
+}
+
+// invalidate forgets token unless another operation has already replaced it
+func (t *authTransport) invalidate(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == token {
+		t.token = ""
+	}
+}
+
+// logout revokes the current access token, if there is one
+func (t *authTransport) logout() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == "" {
+		return nil
+	}
+
+	_, err := api_auth.New(withToken(t.transport, t.token), strfmt.Default).Logout(api_auth.NewLogoutParams())
+	t.token = ""
+
+	return err
+}
+
+// Logout revokes the access tokens of every provider configured by this process. It should be called once the
+// plugin has stopped serving.
+func Logout() {
+	activeAuthTransports.Lock()
+	defer activeAuthTransports.Unlock()
+
+	for _, t := range activeAuthTransports.list {
+		if err := t.logout(); err != nil {
+			log.Printf("[WARN] Error while logging out of the Looker API, %s", err.Error())
+		}
+	}
+
+	activeAuthTransports.list = nil
+}
+
+// tokenTransport adds a fixed access token to operations that do not carry their own authentication
+type tokenTransport struct {
+	transport runtime.ClientTransport
+	authInfo  runtime.ClientAuthInfoWriter
+}
+
+func withToken(transport runtime.ClientTransport, token string) runtime.ClientTransport {
+	return &tokenTransport{
+		transport: transport,
+		authInfo:  httptransport.APIKeyAuth("Authorization", "header", "token "+token),
+	}
+}
+
+// Submit implements runtime.ClientTransport
+func (t *tokenTransport) Submit(operation *runtime.ClientOperation) (interface{}, error) {
+	op := *operation
+	if op.AuthInfo == nil {
+		op.AuthInfo = t.authInfo
+	}
+
+	return t.transport.Submit(&op)
+}
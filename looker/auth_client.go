@@ -0,0 +1,108 @@
+package looker
+
+import (
+	"net/http"
+
+	"github.com/billtrust/looker-go-sdk/client/auth"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+// authConfig is a SAML, OIDC or LDAP configuration as JSON. The SDK models omit false booleans and send the
+// read-only fields back, so the configurations are exchanged as maps holding only the fields to change.
+type authConfig map[string]interface{}
+
+// authClient reads and writes the authentication configurations as authConfig
+type authClient struct {
+	*auth.Client
+	transport runtime.ClientTransport
+}
+
+func (c *authClient) samlConfig() (authConfig, error) {
+	return c.submit("saml_config", "GET", "/saml_config", nil, &auth.SamlConfigReader{})
+}
+
+func (c *authClient) updateSamlConfig(body authConfig) (authConfig, error) {
+	return c.submit("update_saml_config", "PATCH", "/saml_config", body, &auth.UpdateSamlConfigReader{})
+}
+
+func (c *authClient) oidcConfig() (authConfig, error) {
+	return c.submit("oidc_config", "GET", "/oidc_config", nil, &auth.OidcConfigReader{})
+}
+
+func (c *authClient) updateOidcConfig(body authConfig) (authConfig, error) {
+	return c.submit("update_oidc_config", "PATCH", "/oidc_config", body, &auth.UpdateOidcConfigReader{})
+}
+
+func (c *authClient) ldapConfig() (authConfig, error) {
+	return c.submit("ldap_config", "GET", "/ldap_config", nil, &auth.LdapConfigReader{})
+}
+
+func (c *authClient) updateLdapConfig(body authConfig) (authConfig, error) {
+	return c.submit("update_ldap_config", "PATCH", "/ldap_config", body, &auth.UpdateLdapConfigReader{})
+}
+
+// testLdapConfigConnection tests the connection to the LDAP server of body, without saving it
+func (c *authClient) testLdapConfigConnection(body authConfig) (*models.LDAPConfigTestResult, error) {
+	result, err := c.transport.Submit(&runtime.ClientOperation{
+		ID:                 "test_ldap_config_connection",
+		Method:             "PUT",
+		PathPattern:        "/ldap_config/test_connection",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			return r.SetBodyParam(body)
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() != http.StatusOK {
+				return (&auth.TestLdapConfigConnectionReader{}).ReadResponse(response, consumer)
+			}
+
+			result := &models.LDAPConfigTestResult{}
+			if err := consumer.Consume(response.Body(), result); err != nil {
+				return nil, err
+			}
+			return result, nil
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*models.LDAPConfigTestResult), nil
+}
+
+// submit runs an authentication configuration operation, decoding errors with the SDK's reader so they keep their
+// types
+func (c *authClient) submit(id string, method string, path string, body authConfig, errorReader runtime.ClientResponseReader) (authConfig, error) {
+	result, err := c.transport.Submit(&runtime.ClientOperation{
+		ID:                 id,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if body != nil {
+				return r.SetBodyParam(body)
+			}
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() != http.StatusOK {
+				return errorReader.ReadResponse(response, consumer)
+			}
+
+			result := authConfig{}
+			if err := consumer.Consume(response.Body(), &result); err != nil {
+				return nil, err
+			}
+			return result, nil
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(authConfig), nil
+}
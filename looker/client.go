@@ -0,0 +1,199 @@
+package looker
+
+import (
+	apiclient "github.com/billtrust/looker-go-sdk/client"
+	"github.com/billtrust/looker-go-sdk/client/connection"
+	"github.com/billtrust/looker-go-sdk/client/content"
+	"github.com/billtrust/looker-go-sdk/client/group"
+	"github.com/billtrust/looker-go-sdk/client/project"
+	"github.com/billtrust/looker-go-sdk/client/role"
+	"github.com/billtrust/looker-go-sdk/client/session"
+	"github.com/billtrust/looker-go-sdk/client/space"
+	"github.com/billtrust/looker-go-sdk/client/user"
+	"github.com/billtrust/looker-go-sdk/client/user_attribute"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+const (
+	apiVersion30 = "3.0"
+	apiVersion40 = "4.0"
+)
+
+// Client is passed to every resource as its meta value. Each API area is an interface covering the operations
+// the provider uses, so resources don't depend on the client generated for a specific API version.
+type Client struct {
+	APIVersion string
+
+	Auth          authAPI
+	Connection    connectionAPI
+	Content       contentAPI
+	Group         groupAPI
+	Project       projectAPI
+	Role          roleAPI
+	Session       sessionAPI
+	Space         spaceAPI
+	User          userAPI
+	UserAttribute userAttributeAPI
+
+	sessions    *sessionManager
+	permissions *permissionValidator
+	dialects    *dialectInfos
+}
+
+// newClient builds a Client on top of transport. For API 4.0 transport must come from newAPI40Transport so the
+// 3.0 operations of the SDK are translated.
+func newClient(transport runtime.ClientTransport, apiVersion string) *Client {
+	sdk := apiclient.New(transport, strfmt.Default)
+
+	connections := &connectionClient{Client: sdk.Connection, transport: transport}
+
+	return &Client{
+		APIVersion:    apiVersion,
+		Auth:          &authClient{Client: sdk.Auth, transport: transport},
+		Connection:    connections,
+		Content:       sdk.Content,
+		Group:         sdk.Group,
+		Project:       &projectClient{Client: sdk.Project, transport: transport},
+		Role:          sdk.Role,
+		Session:       sdk.Session,
+		Space:         sdk.Space,
+		User:          &userClient{Client: sdk.User, transport: transport},
+		UserAttribute: &userAttributeClient{Client: sdk.UserAttribute, transport: transport},
+		sessions:      newSessionManager(sdk.Session),
+		permissions:   newPermissionValidator(apiVersion, false, false, sdk.Role),
+		dialects:      newDialectInfos(connections),
+	}
+}
+
+type authAPI interface {
+	samlConfig() (authConfig, error)
+	updateSamlConfig(body authConfig) (authConfig, error)
+	oidcConfig() (authConfig, error)
+	updateOidcConfig(body authConfig) (authConfig, error)
+	ldapConfig() (authConfig, error)
+	updateLdapConfig(body authConfig) (authConfig, error)
+	testLdapConfigConnection(body authConfig) (*models.LDAPConfigTestResult, error)
+}
+
+type connectionAPI interface {
+	AllDialectInfos(params *connection.AllDialectInfosParams) (*connection.AllDialectInfosOK, error)
+	DeleteConnection(params *connection.DeleteConnectionParams) (*connection.DeleteConnectionNoContent, error)
+	TestConnection(params *connection.TestConnectionParams) (*connection.TestConnectionOK, error)
+
+	getConnection(name string) (*dbConnection, error)
+	createConnection(body *dbConnection) (*dbConnection, error)
+	updateConnection(name string, body *dbConnection) (*dbConnection, error)
+}
+
+type contentAPI interface {
+	AllContentMetadataAccesss(params *content.AllContentMetadataAccesssParams) (*content.AllContentMetadataAccesssOK, error)
+	ContentMetadata(params *content.ContentMetadataParams) (*content.ContentMetadataOK, error)
+	CreateContentMetadataAccess(params *content.CreateContentMetadataAccessParams) (*content.CreateContentMetadataAccessOK, error)
+	DeleteContentMetadataAccess(params *content.DeleteContentMetadataAccessParams) (*content.DeleteContentMetadataAccessNoContent, error)
+	UpdateContentMetadata(params *content.UpdateContentMetadataParams) (*content.UpdateContentMetadataOK, error)
+}
+
+type groupAPI interface {
+	AddGroupGroup(params *group.AddGroupGroupParams) (*group.AddGroupGroupOK, error)
+	AddGroupUser(params *group.AddGroupUserParams) (*group.AddGroupUserOK, error)
+	AllGroupGroups(params *group.AllGroupGroupsParams) (*group.AllGroupGroupsOK, error)
+	AllGroupUsers(params *group.AllGroupUsersParams) (*group.AllGroupUsersOK, error)
+	AllGroups(params *group.AllGroupsParams) (*group.AllGroupsOK, error)
+	CreateGroup(params *group.CreateGroupParams) (*group.CreateGroupOK, error)
+	DeleteGroup(params *group.DeleteGroupParams) (*group.DeleteGroupNoContent, error)
+	DeleteGroupFromGroup(params *group.DeleteGroupFromGroupParams) (*group.DeleteGroupFromGroupNoContent, error)
+	DeleteGroupUser(params *group.DeleteGroupUserParams) (*group.DeleteGroupUserNoContent, error)
+	Group(params *group.GroupParams) (*group.GroupOK, error)
+	UpdateGroup(params *group.UpdateGroupParams) (*group.UpdateGroupOK, error)
+}
+
+type projectAPI interface {
+	AllGitConnectionTests(params *project.AllGitConnectionTestsParams) (*project.AllGitConnectionTestsOK, error)
+	CreateGitDeployKey(params *project.CreateGitDeployKeyParams) (*project.CreateGitDeployKeyOK, error)
+	GitDeployKey(params *project.GitDeployKeyParams) (*project.GitDeployKeyOK, error)
+	RunGitConnectionTest(params *project.RunGitConnectionTestParams) (*project.RunGitConnectionTestOK, error)
+
+	getProject(projectID string) (*lookerProject, error)
+	createProject(body *lookerProject) (*lookerProject, error)
+	updateProject(projectID string, body interface{}) (*lookerProject, error)
+}
+
+type roleAPI interface {
+	AllModelSets(params *role.AllModelSetsParams) (*role.AllModelSetsOK, error)
+	AllPermissions(params *role.AllPermissionsParams) (*role.AllPermissionsOK, error)
+	AllPermissionSets(params *role.AllPermissionSetsParams) (*role.AllPermissionSetsOK, error)
+	AllRoles(params *role.AllRolesParams) (*role.AllRolesOK, error)
+	CreateModelSet(params *role.CreateModelSetParams) (*role.CreateModelSetOK, error)
+	CreatePermissionSet(params *role.CreatePermissionSetParams) (*role.CreatePermissionSetOK, error)
+	CreateRole(params *role.CreateRoleParams) (*role.CreateRoleOK, error)
+	DeleteModelSet(params *role.DeleteModelSetParams) (*role.DeleteModelSetNoContent, error)
+	DeletePermissionSet(params *role.DeletePermissionSetParams) (*role.DeletePermissionSetNoContent, error)
+	DeleteRole(params *role.DeleteRoleParams) (*role.DeleteRoleNoContent, error)
+	ModelSet(params *role.ModelSetParams) (*role.ModelSetOK, error)
+	PermissionSet(params *role.PermissionSetParams) (*role.PermissionSetOK, error)
+	Role(params *role.RoleParams) (*role.RoleOK, error)
+	RoleGroups(params *role.RoleGroupsParams) (*role.RoleGroupsOK, error)
+	SetRoleGroups(params *role.SetRoleGroupsParams) (*role.SetRoleGroupsOK, error)
+	UpdateModelSet(params *role.UpdateModelSetParams) (*role.UpdateModelSetOK, error)
+	UpdatePermissionSet(params *role.UpdatePermissionSetParams) (*role.UpdatePermissionSetOK, error)
+	UpdateRole(params *role.UpdateRoleParams) (*role.UpdateRoleOK, error)
+}
+
+type sessionAPI interface {
+	UpdateSession(params *session.UpdateSessionParams) (*session.UpdateSessionOK, error)
+}
+
+type spaceAPI interface {
+	CreateSpace(params *space.CreateSpaceParams) (*space.CreateSpaceOK, error)
+	DeleteSpace(params *space.DeleteSpaceParams) (*space.DeleteSpaceNoContent, error)
+	SearchSpaces(params *space.SearchSpacesParams) (*space.SearchSpacesOK, error)
+	Space(params *space.SpaceParams) (*space.SpaceOK, error)
+	UpdateSpace(params *space.UpdateSpaceParams) (*space.UpdateSpaceOK, error)
+}
+
+type userAPI interface {
+	AllUserCredentialsEmbeds(params *user.AllUserCredentialsEmbedsParams) (*user.AllUserCredentialsEmbedsOK, error)
+	CreateUser(params *user.CreateUserParams) (*user.CreateUserOK, error)
+	CreateUserCredentialsEmail(params *user.CreateUserCredentialsEmailParams) (*user.CreateUserCredentialsEmailOK, error)
+	CreateUserCredentialsEmailPasswordReset(params *user.CreateUserCredentialsEmailPasswordResetParams) (*user.CreateUserCredentialsEmailPasswordResetOK, error)
+	DeleteUser(params *user.DeleteUserParams) (*user.DeleteUserNoContent, error)
+	DeleteUserAttributeUserValue(params *user.DeleteUserAttributeUserValueParams) (*user.DeleteUserAttributeUserValueNoContent, error)
+	DeleteUserCredentialsApi3(params *user.DeleteUserCredentialsApi3Params) (*user.DeleteUserCredentialsApi3NoContent, error)
+	DeleteUserCredentialsEmail(params *user.DeleteUserCredentialsEmailParams) (*user.DeleteUserCredentialsEmailNoContent, error)
+	DeleteUserCredentialsEmbed(params *user.DeleteUserCredentialsEmbedParams) (*user.DeleteUserCredentialsEmbedNoContent, error)
+	DeleteUserCredentialsGoogle(params *user.DeleteUserCredentialsGoogleParams) (*user.DeleteUserCredentialsGoogleNoContent, error)
+	DeleteUserCredentialsLdap(params *user.DeleteUserCredentialsLdapParams) (*user.DeleteUserCredentialsLdapNoContent, error)
+	DeleteUserCredentialsOidc(params *user.DeleteUserCredentialsOidcParams) (*user.DeleteUserCredentialsOidcNoContent, error)
+	DeleteUserCredentialsSaml(params *user.DeleteUserCredentialsSamlParams) (*user.DeleteUserCredentialsSamlNoContent, error)
+	SetUserAttributeUserValue(params *user.SetUserAttributeUserValueParams) (*user.SetUserAttributeUserValueOK, error)
+	SetUserRoles(params *user.SetUserRolesParams) (*user.SetUserRolesOK, error)
+	UpdateUser(params *user.UpdateUserParams) (*user.UpdateUserOK, error)
+	UpdateUserCredentialsEmail(params *user.UpdateUserCredentialsEmailParams) (*user.UpdateUserCredentialsEmailOK, error)
+	User(params *user.UserParams) (*user.UserOK, error)
+	UserAttributeUserValues(params *user.UserAttributeUserValuesParams) (*user.UserAttributeUserValuesOK, error)
+	UserCredentialsApi3(params *user.UserCredentialsApi3Params) (*user.UserCredentialsApi3OK, error)
+	UserCredentialsEmail(params *user.UserCredentialsEmailParams) (*user.UserCredentialsEmailOK, error)
+	UserCredentialsGoogle(params *user.UserCredentialsGoogleParams) (*user.UserCredentialsGoogleOK, error)
+	UserCredentialsLdap(params *user.UserCredentialsLdapParams) (*user.UserCredentialsLdapOK, error)
+	UserCredentialsOidc(params *user.UserCredentialsOidcParams) (*user.UserCredentialsOidcOK, error)
+	UserCredentialsSaml(params *user.UserCredentialsSamlParams) (*user.UserCredentialsSamlOK, error)
+	UserForCredential(params *user.UserForCredentialParams) (*user.UserForCredentialOK, error)
+	UserRoles(params *user.UserRolesParams) (*user.UserRolesOK, error)
+
+	getUser(userID int64) (*lookerUser, error)
+	createUser(body *userBody) (*lookerUser, error)
+	updateUser(userID int64, body *userBody) (*lookerUser, error)
+	sendPasswordResetEmail(userID int64) (*models.CredentialsEmail, error)
+	createCredentialsAPI3(userID int64) (*credentialsAPI3, error)
+}
+
+type userAttributeAPI interface {
+	AllUserAttributeGroupValues(params *user_attribute.AllUserAttributeGroupValuesParams) (*user_attribute.AllUserAttributeGroupValuesOK, error)
+	CreateUserAttribute(params *user_attribute.CreateUserAttributeParams) (*user_attribute.CreateUserAttributeOK, error)
+	DeleteUserAttribute(params *user_attribute.DeleteUserAttributeParams) (*user_attribute.DeleteUserAttributeNoContent, error)
+	SetUserAttributeGroupValues(params *user_attribute.SetUserAttributeGroupValuesParams) (*user_attribute.SetUserAttributeGroupValuesOK, error)
+	UpdateUserAttribute(params *user_attribute.UpdateUserAttributeParams) (*user_attribute.UpdateUserAttributeOK, error)
+	UserAttribute(params *user_attribute.UserAttributeParams) (*user_attribute.UserAttributeOK, error)
+}
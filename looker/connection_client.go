@@ -0,0 +1,80 @@
+package looker
+
+import (
+	"net/http"
+
+	"github.com/billtrust/looker-go-sdk/client/connection"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+// dbConnection is a connection as the provider exchanges it with the API. models.DBConnection lacks the fields
+// added to the API after the SDK was generated, and omits false booleans, which would leave them at their previous
+// value on PATCH. The booleans are pointers so the ones left out of the configuration aren't sent at all.
+type dbConnection struct {
+	*models.DBConnection
+	Ssl                     *bool  `json:"ssl,omitempty"`
+	VerifySsl               *bool  `json:"verify_ssl,omitempty"`
+	SQLRunnerPrecacheTables *bool  `json:"sql_runner_precache_tables,omitempty"`
+	UserDbCredentials       *bool  `json:"user_db_credentials,omitempty"`
+	PdtConcurrency          int64  `json:"pdt_concurrency,omitempty"`
+	UsesOauth               *bool  `json:"uses_oauth,omitempty"`
+	OauthApplicationID      string `json:"oauth_application_id,omitempty"`
+}
+
+// connectionClient reads and writes connections as dbConnection, and leaves the other operations to the SDK
+type connectionClient struct {
+	*connection.Client
+	transport runtime.ClientTransport
+}
+
+func (c *connectionClient) getConnection(name string) (*dbConnection, error) {
+	return c.submit("connection", "GET", "/connections/{connection_name}", name, nil, &connection.ConnectionReader{})
+}
+
+func (c *connectionClient) createConnection(body *dbConnection) (*dbConnection, error) {
+	return c.submit("create_connection", "POST", "/connections", "", body, &connection.CreateConnectionReader{})
+}
+
+func (c *connectionClient) updateConnection(name string, body *dbConnection) (*dbConnection, error) {
+	return c.submit("update_connection", "PATCH", "/connections/{connection_name}", name, body, &connection.UpdateConnectionReader{})
+}
+
+// submit runs a connection operation, decoding errors with the SDK's reader so they keep their types
+func (c *connectionClient) submit(id string, method string, path string, name string, body *dbConnection, errorReader runtime.ClientResponseReader) (*dbConnection, error) {
+	result, err := c.transport.Submit(&runtime.ClientOperation{
+		ID:                 id,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if name != "" {
+				if err := r.SetPathParam("connection_name", name); err != nil {
+					return err
+				}
+			}
+			if body != nil {
+				return r.SetBodyParam(body)
+			}
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() != http.StatusOK {
+				return errorReader.ReadResponse(response, consumer)
+			}
+
+			result := &dbConnection{DBConnection: &models.DBConnection{}}
+			if err := consumer.Consume(response.Body(), result); err != nil {
+				return nil, err
+			}
+			return result, nil
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*dbConnection), nil
+}
@@ -0,0 +1,164 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/billtrust/looker-go-sdk/client/connection"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceConnectionTest is looker_connection_test. The file isn't named after it, as Go would take it for tests.
+func dataSourceConnectionTest() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectionTestRead,
+
+		Schema: map[string]*schema.Schema{
+			"connection_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// all the tests of the connection's dialect when empty
+			"tests": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"results": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"message": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"connection_string": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			// whether none of the tests failed
+			"success": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceConnectionTestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	name := d.Get("connection_name").(string)
+
+	results, err := runConnectionTests(client, name, getStringArray(d, "tests"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	values := []map[string]interface{}{}
+	for _, result := range results {
+		values = append(values, map[string]interface{}{
+			"name":              result.Name,
+			"status":            result.Status,
+			"message":           result.Message,
+			"connection_string": result.ConnectionString,
+		})
+	}
+
+	d.SetId(name)
+	d.Set("results", values)
+	d.Set("success", len(failedConnectionTests(results)) == 0)
+
+	return nil
+}
+
+// runConnectionTests runs the given tests of a connection, or all the tests of its dialect if there are none
+func runConnectionTests(client *Client, name string, tests []string) ([]*models.DBConnectionTestResult, error) {
+	if len(tests) == 0 {
+		result, err := client.Connection.getConnection(name)
+		if err != nil {
+			return nil, err
+		}
+		if result.Dialect != nil {
+			tests = result.Dialect.ConnectionTests
+		}
+	}
+
+	params := connection.NewTestConnectionParams()
+	params.ConnectionName = name
+	params.Tests = tests
+
+	result, err := client.Connection.TestConnection(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Payload, nil
+}
+
+// failedConnectionTests returns the results of the tests that neither succeeded nor were skipped
+func failedConnectionTests(results []*models.DBConnectionTestResult) []*models.DBConnectionTestResult {
+	failed := []*models.DBConnectionTestResult{}
+	for _, result := range results {
+		if result.Status != "success" && result.Status != "skipped" {
+			failed = append(failed, result)
+		}
+	}
+
+	return failed
+}
+
+// checkConnection runs the tests of test_on_apply, if configured, and fails with the results of all of them when one
+// fails
+func checkConnection(client *Client, d *schema.ResourceData) diag.Diagnostics {
+	blocks := d.Get("test_on_apply").([]interface{})
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	tests := []string{}
+	if block, ok := blocks[0].(map[string]interface{}); ok {
+		for _, test := range block["tests"].([]interface{}) {
+			tests = append(tests, test.(string))
+		}
+	}
+
+	results, err := runConnectionTests(client, d.Id(), tests)
+	if err != nil {
+		return diag.Errorf("Can't test connection %s: %s", d.Id(), err)
+	}
+
+	if len(failedConnectionTests(results)) == 0 {
+		return nil
+	}
+
+	lines := []string{}
+	for _, result := range results {
+		line := fmt.Sprintf("%s: %s", result.Name, result.Status)
+		if result.Message != "" {
+			line += " - " + result.Message
+		}
+		lines = append(lines, line)
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Connection %s failed its tests", d.Id()),
+		Detail:   strings.Join(lines, "\n"),
+	}}
+}
@@ -0,0 +1,87 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDialectInfo() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDialectInfoRead,
+
+		Schema: map[string]*schema.Schema{
+			"dialects": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"installed": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"default_port": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"default_max_connections": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label_for_database_equivalent": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						// the looker_connection fields the dialect supports, e.g. "tmp_db_name" = true
+						"supported_options": &schema.Schema{
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeBool},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDialectInfoRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	infos, err := getAllDialectInfos(client.Connection)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	dialects := []map[string]interface{}{}
+	for _, info := range infos {
+		supported := map[string]interface{}{}
+		for field, ok := range dialectSupports(info) {
+			supported[field] = ok
+		}
+
+		dialects = append(dialects, map[string]interface{}{
+			"name":                          info.Name,
+			"label":                         info.Label,
+			"installed":                     info.Installed == nil || *info.Installed,
+			"default_port":                  info.DefaultPort,
+			"default_max_connections":       info.DefaultMaxConnections,
+			"label_for_database_equivalent": info.LabelForDatabaseEquivalent,
+			"supported_options":             supported,
+		})
+	}
+
+	d.SetId("dialect_info")
+	d.Set("dialects", dialects)
+
+	return nil
+}
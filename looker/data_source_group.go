@@ -0,0 +1,84 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/billtrust/looker-go-sdk/client/group"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"externally_managed": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"user_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	var result *models.Group
+
+	if d.Get("id").(string) != "" {
+		ID, err := getIDFromString(d.Get("id").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		params := group.NewGroupParams()
+		params.GroupID = ID
+
+		resp, err := client.Group.Group(params)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = resp.Payload
+	} else {
+		resp, err := client.Group.AllGroups(group.NewAllGroupsParams())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		names := []string{}
+		for _, g := range resp.Payload {
+			names = append(names, g.Name)
+		}
+
+		i, err := getIndexByName("group", d.Get("name").(string), names)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = resp.Payload[i]
+	}
+
+	d.SetId(getStringFromID(result.ID))
+	d.Set("name", result.Name)
+	d.Set("externally_managed", result.ExternallyManaged != nil && *result.ExternallyManaged)
+	d.Set("user_count", result.UserCount)
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/billtrust/looker-go-sdk/client/role"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePermissionSet() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePermissionSetRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"permissions": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"all_access": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"built_in": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourcePermissionSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	var result *models.PermissionSet
+
+	if d.Get("id").(string) != "" {
+		ID, err := getIDFromString(d.Get("id").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		params := role.NewPermissionSetParams()
+		params.PermissionSetID = ID
+
+		resp, err := client.Role.PermissionSet(params)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = resp.Payload
+	} else {
+		resp, err := client.Role.AllPermissionSets(role.NewAllPermissionSetsParams())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		names := []string{}
+		for _, s := range resp.Payload {
+			names = append(names, s.Name)
+		}
+
+		i, err := getIndexByName("permission set", d.Get("name").(string), names)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = resp.Payload[i]
+	}
+
+	d.SetId(getStringFromID(result.ID))
+	d.Set("name", result.Name)
+	d.Set("permissions", result.Permissions)
+	d.Set("all_access", result.AllAccess != nil && *result.AllAccess)
+	d.Set("built_in", result.BuiltIn != nil && *result.BuiltIn)
+
+	return nil
+}
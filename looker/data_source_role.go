@@ -0,0 +1,84 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/billtrust/looker-go-sdk/client/role"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRole() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRoleRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"permission_set_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"model_set_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	var result *models.Role
+
+	if d.Get("id").(string) != "" {
+		ID, err := getIDFromString(d.Get("id").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		params := role.NewRoleParams()
+		params.RoleID = ID
+
+		resp, err := client.Role.Role(params)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = resp.Payload
+	} else {
+		resp, err := client.Role.AllRoles(role.NewAllRolesParams())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		names := []string{}
+		for _, r := range resp.Payload {
+			names = append(names, r.Name)
+		}
+
+		i, err := getIndexByName("role", d.Get("name").(string), names)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = resp.Payload[i]
+	}
+
+	d.SetId(getStringFromID(result.ID))
+	d.Set("name", result.Name)
+	d.Set("permission_set_id", getStringFromID(result.PermissionSetID))
+	d.Set("model_set_id", getStringFromID(result.ModelSetID))
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/billtrust/looker-go-sdk/client/user"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var dataSourceUserLookupKeys = []string{"id", "email", "embed_external_user_id"}
+
+func dataSourceUser() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUserRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: dataSourceUserLookupKeys,
+			},
+			"email": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: dataSourceUserLookupKeys,
+			},
+			"embed_external_user_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: dataSourceUserLookupKeys,
+			},
+			"first_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"display_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_disabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"role_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"group_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"personal_folder_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"credential_types": &schema.Schema{
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	var result *models.User
+
+	switch {
+	case d.Get("id").(string) != "":
+		userID, err := getIDFromString(d.Get("id").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		params := user.NewUserParams()
+		params.UserID = userID
+
+		resp, err := client.User.User(params)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = resp.Payload
+	case d.Get("email").(string) != "":
+		resp, err := getUserForCredential(client, "email", d.Get("email").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = resp
+	default:
+		resp, err := getUserForCredential(client, "embed", d.Get("embed_external_user_id").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		result = resp
+	}
+
+	d.SetId(getStringFromID(result.ID))
+	d.Set("first_name", result.FirstName)
+	d.Set("last_name", result.LastName)
+	d.Set("display_name", result.DisplayName)
+	d.Set("is_disabled", result.IsDisabled)
+	d.Set("role_ids", getStringsFromIDs(result.RoleIds))
+	d.Set("group_ids", getStringsFromIDs(result.GroupIds))
+	if result.PersonalSpaceID != 0 {
+		d.Set("personal_folder_id", getStringFromID(result.PersonalSpaceID))
+	}
+	d.Set("credential_types", getUserCredentialTypes(result))
+
+	if result.CredentialsEmail != nil {
+		d.Set("email", result.CredentialsEmail.Email)
+	} else {
+		d.Set("email", result.Email)
+	}
+
+	for _, embed := range result.CredentialsEmbed {
+		d.Set("embed_external_user_id", embed.ExternalUserID)
+	}
+
+	return nil
+}
+
+func getUserForCredential(client *Client, credentialType string, credentialID string) (*models.User, error) {
+	params := user.NewUserForCredentialParams()
+	params.CredentialType = credentialType
+	params.CredentialID = credentialID
+
+	result, err := client.User.UserForCredential(params)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, newNotFoundError("No user with %s credentials %q", credentialType, credentialID)
+		}
+		return nil, err
+	}
+
+	return result.Payload, nil
+}
+
+func getUserCredentialTypes(u *models.User) []string {
+	types := []string{}
+	if u.CredentialsEmail != nil {
+		types = append(types, "email")
+	}
+	if len(u.CredentialsEmbed) > 0 {
+		types = append(types, "embed")
+	}
+	if u.CredentialsGoogle != nil {
+		types = append(types, "google")
+	}
+	if u.CredentialsLdap != nil {
+		types = append(types, "ldap")
+	}
+	if u.CredentialsOidc != nil {
+		types = append(types, "oidc")
+	}
+	if u.CredentialsSaml != nil {
+		types = append(types, "saml")
+	}
+	if u.CredentialsLookerOpenid != nil {
+		types = append(types, "looker_openid")
+	}
+	if u.CredentialsTotp != nil {
+		types = append(types, "totp")
+	}
+	if len(u.CredentialsApi3) > 0 {
+		types = append(types, "api3")
+	}
+	return types
+}
@@ -0,0 +1,87 @@
+package looker
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/billtrust/looker-go-sdk/client/connection"
+	"github.com/billtrust/looker-go-sdk/models"
+)
+
+// dialectInfos lists the dialects of the instance once, for the connections validated during a plan
+type dialectInfos struct {
+	connection connectionAPI
+
+	once  sync.Once
+	infos []*models.DialectInfo
+	err   error
+}
+
+func newDialectInfos(connection connectionAPI) *dialectInfos {
+	return &dialectInfos{connection: connection}
+}
+
+func (d *dialectInfos) all() ([]*models.DialectInfo, error) {
+	d.once.Do(func() {
+		d.infos, d.err = getAllDialectInfos(d.connection)
+	})
+
+	return d.infos, d.err
+}
+
+// get returns the dialect named name, failing when the instance doesn't know it or doesn't have its driver
+func (d *dialectInfos) get(name string) (*models.DialectInfo, error) {
+	infos, err := d.all()
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, info := range infos {
+		if info.Name == name {
+			if info.Installed != nil && !*info.Installed {
+				return nil, fmt.Errorf("dialect_name: the %s dialect is not installed on this Looker instance", name)
+			}
+			return info, nil
+		}
+		names = append(names, info.Name)
+	}
+
+	return nil, newNotFoundError("dialect_name: %q is not a dialect of this Looker instance%s", name, didYouMean(name, names))
+}
+
+func getAllDialectInfos(client connectionAPI) ([]*models.DialectInfo, error) {
+	result, err := client.AllDialectInfos(connection.NewAllDialectInfosParams())
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Payload, nil
+}
+
+// dialectSupports returns the supported options of a dialect by the names of the fields of looker_connection
+// they govern
+func dialectSupports(info *models.DialectInfo) map[string]bool {
+	options := info.SupportedOptions
+	if options == nil {
+		options = &models.DialectInfoOptions{}
+	}
+
+	supported := func(option *bool) bool {
+		return option != nil && *option
+	}
+
+	return map[string]bool{
+		"host":                   supported(options.Host),
+		"schema":                 supported(options.Schema),
+		"jdbc_additional_params": supported(options.AdditionalParams),
+		"ssl":                    supported(options.Ssl),
+		"verify_ssl":             supported(options.Ssl),
+		"db_timezone":            supported(options.Timezone),
+		"query_timezone":         supported(options.Timezone),
+		"tmp_db_name":            supported(options.TmpTable),
+		"pdt_concurrency":        supported(options.TmpTable),
+		"uses_oauth":             supported(options.OauthCredentials),
+		"oauth_application_id":   supported(options.OauthCredentials),
+	}
+}
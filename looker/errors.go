@@ -0,0 +1,178 @@
+package looker
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/go-openapi/runtime"
+)
+
+type apiErrorKind int
+
+const (
+	apiErrorUnknown apiErrorKind = iota
+	apiErrorNotFound
+	apiErrorConflict
+	apiErrorValidation
+	apiErrorAuth
+	apiErrorRateLimited
+	apiErrorServer
+)
+
+func (k apiErrorKind) String() string {
+	switch k {
+	case apiErrorNotFound:
+		return "not found"
+	case apiErrorConflict:
+		return "conflict"
+	case apiErrorValidation:
+		return "validation"
+	case apiErrorAuth:
+		return "auth"
+	case apiErrorRateLimited:
+		return "rate limited"
+	case apiErrorServer:
+		return "server"
+	}
+	return "unknown"
+}
+
+// The generated SDK returns a typed error for every documented response, e.g. *user.UserNotFound or
+// *role.CreateRoleUnprocessableEntity. The status code is only available from the type name.
+var sdkErrorSuffixes = []struct {
+	suffix string
+	status int
+}{
+	{"UnprocessableEntity", http.StatusUnprocessableEntity},
+	{"InternalServerError", http.StatusInternalServerError},
+	{"TooManyRequests", http.StatusTooManyRequests},
+	{"Unauthorized", http.StatusUnauthorized},
+	{"BadRequest", http.StatusBadRequest},
+	{"Forbidden", http.StatusForbidden},
+	{"NotFound", http.StatusNotFound},
+	{"Conflict", http.StatusConflict},
+}
+
+// notFoundError is returned by helpers that search for an object the API has no direct GET for
+type notFoundError struct {
+	message string
+}
+
+func (e *notFoundError) Error() string {
+	return e.message
+}
+
+func newNotFoundError(format string, a ...interface{}) error {
+	return &notFoundError{message: fmt.Sprintf(format, a...)}
+}
+
+// apiErrorStatus returns the HTTP status code behind err, or 0 if err did not come from an API response
+func apiErrorStatus(err error) int {
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch e := err.(type) {
+		case *runtime.APIError:
+			return e.Code
+		case *notFoundError:
+			return http.StatusNotFound
+		}
+
+		t := reflect.TypeOf(err)
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if !strings.HasPrefix(t.PkgPath(), "github.com/billtrust/looker-go-sdk/") {
+			continue
+		}
+		for _, s := range sdkErrorSuffixes {
+			if strings.HasSuffix(t.Name(), s.suffix) {
+				return s.status
+			}
+		}
+	}
+
+	return 0
+}
+
+// apiErrorContains reports whether the message of err, or of one of the validation errors it carries, contains substr.
+// The SDK's typed errors only print the address of their validation errors.
+func apiErrorContains(err error, substr string) bool {
+	if err == nil {
+		return false
+	}
+	if strings.Contains(err.Error(), substr) {
+		return true
+	}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.ValueOf(err)
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+		payload := v.FieldByName("Payload")
+		if !payload.IsValid() || !payload.CanInterface() {
+			continue
+		}
+
+		switch p := payload.Interface().(type) {
+		case *models.ValidationError:
+			if p == nil {
+				continue
+			}
+			if strings.Contains(p.Message, substr) {
+				return true
+			}
+			for _, detail := range p.Errors {
+				if detail != nil && strings.Contains(detail.Message, substr) {
+					return true
+				}
+			}
+		case *models.Error:
+			if p != nil && strings.Contains(p.Message, substr) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func classifyAPIError(err error) apiErrorKind {
+	status := apiErrorStatus(err)
+	switch {
+	case status == http.StatusNotFound:
+		return apiErrorNotFound
+	case status == http.StatusConflict:
+		return apiErrorConflict
+	case status == http.StatusUnprocessableEntity:
+		return apiErrorValidation
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return apiErrorAuth
+	case status == http.StatusTooManyRequests:
+		return apiErrorRateLimited
+	case status >= 500:
+		return apiErrorServer
+	}
+	return apiErrorUnknown
+}
+
+func isNotFound(err error) bool {
+	return classifyAPIError(err) == apiErrorNotFound
+}
+
+// isRejected reports whether the API refused a request because of the state of the objects it involves, which Looker
+// reports either as a validation error or a conflict
+func isRejected(err error) bool {
+	kind := classifyAPIError(err)
+	return kind == apiErrorValidation || kind == apiErrorConflict
+}
+
+func isServerError(err error) bool {
+	return classifyAPIError(err) == apiErrorServer
+}
@@ -0,0 +1,170 @@
+package looker
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/billtrust/looker-go-sdk/client/content"
+	"github.com/billtrust/looker-go-sdk/client/group"
+	"github.com/billtrust/looker-go-sdk/client/project"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+func TestAPIErrorStatus(t *testing.T) {
+	var status int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, `{"message": "canned error", "documentation_url": "https://docs.looker.com/"}`)
+	}))
+	defer server.Close()
+
+	transport := httptransport.New(strings.TrimPrefix(server.URL, "http://"), "/api/3.0/", []string{"http"})
+	groups := group.New(transport, strfmt.Default)
+	projects := project.New(transport, strfmt.Default)
+
+	createGroup := func() error {
+		params := group.NewCreateGroupParams()
+		params.Body = &models.Group{Name: "Analysts"}
+		_, err := groups.CreateGroup(params)
+		return err
+	}
+	addGroupUser := func() error {
+		params := group.NewAddGroupUserParams()
+		params.GroupID = 1
+		params.Body = &models.GroupIDForGroupUserInclusion{UserID: 2}
+		_, err := groups.AddGroupUser(params)
+		return err
+	}
+	updateProject := func() error {
+		params := project.NewUpdateProjectParams()
+		params.ProjectID = "analytics"
+		params.Body = &models.Project{Name: "analytics"}
+		_, err := projects.UpdateProject(params)
+		return err
+	}
+
+	tests := []struct {
+		name   string
+		call   func() error
+		status int
+		want   int
+		kind   apiErrorKind
+		// whether the operation doesn't document the status, so the SDK returns a *runtime.APIError
+		undocumented bool
+	}{
+		{"bad request", createGroup, http.StatusBadRequest, http.StatusBadRequest, apiErrorUnknown, false},
+		{"not found", createGroup, http.StatusNotFound, http.StatusNotFound, apiErrorNotFound, false},
+		{"conflict", createGroup, http.StatusConflict, http.StatusConflict, apiErrorConflict, false},
+		{"unprocessable entity", createGroup, http.StatusUnprocessableEntity, http.StatusUnprocessableEntity, apiErrorValidation, false},
+		{"forbidden", addGroupUser, http.StatusForbidden, http.StatusForbidden, apiErrorAuth, false},
+		{"internal server error", updateProject, http.StatusInternalServerError, http.StatusInternalServerError, apiErrorServer, false},
+		{"undocumented unauthorized", createGroup, http.StatusUnauthorized, http.StatusUnauthorized, apiErrorAuth, true},
+		{"undocumented too many requests", addGroupUser, http.StatusTooManyRequests, http.StatusTooManyRequests, apiErrorRateLimited, true},
+		{"undocumented unprocessable entity", addGroupUser, http.StatusUnprocessableEntity, http.StatusUnprocessableEntity, apiErrorValidation, true},
+		{"undocumented server error", createGroup, http.StatusBadGateway, http.StatusBadGateway, apiErrorServer, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status = test.status
+			err := test.call()
+			if err == nil {
+				t.Fatalf("expected an error for status %d", test.status)
+			}
+			if _, ok := err.(*runtime.APIError); ok != test.undocumented {
+				t.Fatalf("got a %T for status %d", err, test.status)
+			}
+
+			if got := apiErrorStatus(err); got != test.want {
+				t.Errorf("apiErrorStatus(%T) = %d, want %d", err, got, test.want)
+			}
+			if got := classifyAPIError(err); got != test.kind {
+				t.Errorf("classifyAPIError(%T) = %s, want %s", err, got, test.kind)
+			}
+
+			wrapped := fmt.Errorf("Can't update: %w", err)
+			if got := apiErrorStatus(wrapped); got != test.want {
+				t.Errorf("apiErrorStatus of wrapped %T = %d, want %d", err, got, test.want)
+			}
+		})
+	}
+}
+
+func TestAPIErrorStatusOtherErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, 0},
+		{"plain error", errors.New("connection refused"), 0},
+		{"not found error", newNotFoundError("No group named %q", "Analysts"), http.StatusNotFound},
+		{"wrapped not found error", fmt.Errorf("Can't read: %w", newNotFoundError("gone")), http.StatusNotFound},
+		// types named like SDK errors outside of the SDK are ignored
+		{"lookalike", &lookalikeNotFound{}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := apiErrorStatus(test.err); got != test.want {
+				t.Errorf("apiErrorStatus(%v) = %d, want %d", test.err, got, test.want)
+			}
+		})
+	}
+
+	if !isNotFound(newNotFoundError("gone")) || isNotFound(errors.New("gone")) {
+		t.Error("isNotFound should only be true for not found errors")
+	}
+	for code, want := range map[int]bool{http.StatusUnprocessableEntity: true, http.StatusConflict: true, http.StatusNotFound: false, http.StatusInternalServerError: false} {
+		if got := isRejected(runtime.NewAPIError("unknown error", "Group already has access on content", code)); got != want {
+			t.Errorf("isRejected of a %d = %t, want %t", code, got, want)
+		}
+	}
+	if isRejected(errors.New("Group already has access on content")) {
+		t.Error("isRejected should ignore errors that aren't API responses")
+	}
+}
+
+func TestAPIErrorContains(t *testing.T) {
+	validation := &content.CreateContentMetadataAccessUnprocessableEntity{Payload: &models.ValidationError{
+		Message: "Validation Failed",
+		Errors:  []*models.ValidationErrorDetail{{Field: "group_id", Message: "Group already has access on content"}},
+	}}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("Group already has access on content"), true},
+		{"validation error detail", validation, true},
+		{"wrapped validation error detail", fmt.Errorf("Can't give access: %w", validation), true},
+		{"validation error message", &content.CreateContentMetadataAccessUnprocessableEntity{Payload: &models.ValidationError{Message: "Group already has access on content"}}, true},
+		{"error message", &content.CreateContentMetadataAccessNotFound{Payload: &models.Error{Message: "Group already has access on content"}}, true},
+		{"other validation error", &content.CreateContentMetadataAccessUnprocessableEntity{Payload: &models.ValidationError{Message: "Validation Failed"}}, false},
+		{"no payload", &content.CreateContentMetadataAccessUnprocessableEntity{}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := apiErrorContains(test.err, "already has access on content"); got != test.want {
+				t.Errorf("apiErrorContains(%v) = %t, want %t", test.err, got, test.want)
+			}
+		})
+	}
+}
+
+type lookalikeNotFound struct{}
+
+func (e *lookalikeNotFound) Error() string {
+	return "not found"
+}
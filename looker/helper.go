@@ -2,28 +2,13 @@ package looker
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
 	"github.com/billtrust/looker-go-sdk/client/role"
-	"github.com/billtrust/looker-go-sdk/client/session"
-	"github.com/billtrust/looker-go-sdk/models"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func updateSession(client *apiclient.LookerAPI30Reference, mode string) error {
-	params := session.NewUpdateSessionParams()
-	params.Body = &models.APISession{}
-	params.Body.WorkspaceID = mode
-
-	_, err := client.Session.UpdateSession(params)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func getStringArray(d *schema.ResourceData, key string) []string {
 	scope := []string{}
 	for _, s := range d.Get(key).([]interface{}) {
@@ -32,7 +17,15 @@ func getStringArray(d *schema.ResourceData, key string) []string {
 	return scope
 }
 
-func getRoleIds(roleNames []string, client *apiclient.LookerAPI30Reference) ([]int64, error) {
+func getStringSet(d *schema.ResourceData, key string) []string {
+	items := []string{}
+	for _, s := range d.Get(key).(*schema.Set).List() {
+		items = append(items, s.(string))
+	}
+	return items
+}
+
+func getRoleIds(roleNames []string, client *Client) ([]int64, error) {
 	rolesOK, err := client.Role.AllRoles(role.NewAllRolesParams())
 	if err != nil {
 		return nil, err
@@ -50,7 +43,7 @@ func getRoleIds(roleNames []string, client *apiclient.LookerAPI30Reference) ([]i
 	return roleIds, nil
 }
 
-func getRoleNames(roleIDs []int64, client *apiclient.LookerAPI30Reference) ([]string, error) {
+func getRoleNames(roleIDs []int64, client *Client) ([]string, error) {
 	rolesOK, err := client.Role.AllRoles(role.NewAllRolesParams())
 	if err != nil {
 		return nil, err
@@ -81,6 +74,14 @@ func getStringFromID(i int64) string {
 	return strconv.FormatInt(i, 10)
 }
 
+func getStringsFromIDs(ids []int64) []string {
+	s := []string{}
+	for _, id := range ids {
+		s = append(s, getStringFromID(id))
+	}
+	return s
+}
+
 func getJSONString(s interface{}) (string, error) {
 	bytes, err := json.Marshal(s)
 	if err != nil {
@@ -89,3 +90,24 @@ func getJSONString(s interface{}) (string, error) {
 
 	return string(bytes), nil
 }
+
+// getIndexByName returns the index of the only one of names equal to name. kind names the type of object in the
+// errors returned when there is no such name or more than one.
+func getIndexByName(kind string, name string, names []string) (int, error) {
+	index := -1
+	for i, n := range names {
+		if n != name {
+			continue
+		}
+		if index != -1 {
+			return 0, fmt.Errorf("More than one %s named %q, look it up by id instead", kind, name)
+		}
+		index = i
+	}
+
+	if index == -1 {
+		return 0, newNotFoundError("No %s named %q", kind, name)
+	}
+
+	return index, nil
+}
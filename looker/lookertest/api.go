@@ -0,0 +1,1226 @@
+package lookertest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST "+basePath+"/login", s.login)
+	s.handle(mux, "DELETE /logout", s.logout)
+	s.handle(mux, "GET /session", s.session)
+	s.handle(mux, "PATCH /session", s.updateSession)
+
+	s.crud(mux, "/users", "users", "user_id")
+	s.handle(mux, "GET /users/{user_id}/credentials_email", s.credentialsEmail)
+	s.handle(mux, "POST /users/{user_id}/credentials_email", s.updateCredentialsEmail)
+	s.handle(mux, "PATCH /users/{user_id}/credentials_email", s.updateCredentialsEmail)
+	s.handle(mux, "DELETE /users/{user_id}/credentials_email", s.deleteCredentialsEmail)
+	s.handle(mux, "POST /users/{user_id}/credentials_email/password_reset", s.passwordReset(false))
+	s.handle(mux, "POST /users/{user_id}/credentials_email/send_password_reset", s.passwordReset(true))
+	s.handle(mux, "POST /users/{user_id}/credentials_api3", s.createCredentialsAPI3)
+	// shared with GET /users/credential/{credential_type}/{credential_id}, which ServeMux can't tell apart from it
+	s.handle(mux, "GET /users/{user_id}/{credential_type}/{credential_id}", s.userCredential)
+	s.handle(mux, "DELETE /users/{user_id}/credentials_api3/{credentials_api3_id}", s.deleteCredentialsAPI3)
+	for _, credentialType := range []string{"saml", "oidc", "ldap", "google"} {
+		s.handle(mux, "GET /users/{user_id}/credentials_"+credentialType, s.getHandler("credentials_"+credentialType, "user_id"))
+		s.handle(mux, "DELETE /users/{user_id}/credentials_"+credentialType, s.deleteHandler("credentials_"+credentialType, "user_id"))
+	}
+	s.handle(mux, "GET /users/{user_id}/credentials_embed", s.credentialsEmbeds)
+	s.handle(mux, "DELETE /users/{user_id}/credentials_embed/{credentials_embed_id}", s.deleteCredentialsEmbed)
+	s.handle(mux, "GET /users/{user_id}/roles", s.userRoles)
+	s.handle(mux, "PUT /users/{user_id}/roles", s.setUserRoles)
+
+	s.crud(mux, "/groups", "groups", "group_id")
+	s.handle(mux, "GET /groups/{group_id}/users", s.groupMembers("users", "user_ids"))
+	s.handle(mux, "POST /groups/{group_id}/users", s.addGroupMember("users", "user_ids", "user_id"))
+	s.handle(mux, "DELETE /groups/{group_id}/users/{member_id}", s.deleteGroupMember("user_ids"))
+	s.handle(mux, "GET /groups/{group_id}/groups", s.groupMembers("groups", "group_ids"))
+	s.handle(mux, "POST /groups/{group_id}/groups", s.addGroupMember("groups", "group_ids", "group_id"))
+	s.handle(mux, "DELETE /groups/{group_id}/groups/{member_id}", s.deleteGroupMember("group_ids"))
+	s.crud(mux, "/permission_sets", "permission_sets", "permission_set_id")
+	s.handle(mux, "GET /permissions", s.permissions)
+	s.crud(mux, "/model_sets", "model_sets", "model_set_id")
+	s.crud(mux, "/user_attributes", "user_attributes", "user_attribute_id")
+	s.handle(mux, "GET /user_attributes/{user_attribute_id}/group_values", s.userAttributeGroupValues)
+	s.handle(mux, "POST /user_attributes/{user_attribute_id}/group_values", s.setUserAttributeGroupValues)
+	s.handle(mux, "GET /users/{user_id}/attribute_values", s.userAttributeUserValues)
+	s.handle(mux, "PATCH /users/{user_id}/attribute_values/{user_attribute_id}", s.setUserAttributeUserValue)
+	s.handle(mux, "DELETE /users/{user_id}/attribute_values/{user_attribute_id}", s.deleteUserAttributeUserValue)
+	s.crud(mux, "/roles", "roles", "role_id")
+	s.handle(mux, "GET /roles/{role_id}/groups", s.roleGroups)
+	s.handle(mux, "PUT /roles/{role_id}/groups", s.setRoleGroups)
+
+	s.handle(mux, "POST /spaces", s.createSpaceHandler)
+	s.handle(mux, "GET /spaces/search", s.searchSpaces)
+	s.handle(mux, "GET /spaces/{space_id}", s.getHandler("spaces", "space_id"))
+	s.handle(mux, "PATCH /spaces/{space_id}", s.updateHandler("spaces", "space_id"))
+	s.handle(mux, "DELETE /spaces/{space_id}", s.deleteSpace)
+	s.handle(mux, "GET /content_metadata/{content_metadata_id}", s.getHandler("content_metadata", "content_metadata_id"))
+	s.handle(mux, "PATCH /content_metadata/{content_metadata_id}", s.updateHandler("content_metadata", "content_metadata_id"))
+	s.handle(mux, "GET /content_metadata_access", s.contentMetadataAccess)
+	s.handle(mux, "POST /content_metadata_access", s.createContentMetadataAccess)
+	s.handle(mux, "DELETE /content_metadata_access/{content_metadata_access_id}", s.deleteHandler("content_metadata_access", "content_metadata_access_id"))
+
+	s.handle(mux, "GET /dialect_info", s.dialectInfo)
+	s.handle(mux, "POST /connections", s.createConnection)
+	s.handle(mux, "GET /connections/{connection_name}", s.connection)
+	s.handle(mux, "PATCH /connections/{connection_name}", s.updateConnection)
+	s.handle(mux, "PUT /connections/{connection_name}/test", s.testConnection)
+	s.handle(mux, "DELETE /connections/{connection_name}", s.deleteHandler("connections", "connection_name"))
+
+	s.handle(mux, "POST /projects", s.createProject)
+	s.handle(mux, "GET /projects/{project_id}", s.project)
+	s.handle(mux, "PATCH /projects/{project_id}", s.updateProject)
+	s.handle(mux, "GET /projects/{project_id}/git/deploy_key", s.gitDeployKey)
+	s.handle(mux, "POST /projects/{project_id}/git/deploy_key", s.createGitDeployKey)
+	s.handle(mux, "GET /projects/{project_id}/git_connection_tests", s.gitConnectionTests)
+	s.handle(mux, "GET /projects/{project_id}/git_connection_tests/{test_id}", s.runGitConnectionTest)
+
+	for _, name := range []string{"saml", "oidc", "ldap"} {
+		s.handle(mux, "GET /"+name+"_config", s.authConfig(name))
+		s.handle(mux, "PATCH /"+name+"_config", s.updateAuthConfig(name))
+	}
+	s.handle(mux, "PUT /ldap_config/test_connection", s.testLdapConnection)
+
+	return mux
+}
+
+// handle registers an authenticated endpoint. Handlers run one at a time with the server locked.
+func (s *Server) handle(mux *http.ServeMux, pattern string, handler http.HandlerFunc) {
+	method, path, _ := strings.Cut(pattern, " ")
+
+	mux.HandleFunc(method+" "+basePath+path, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.sessions[token(r)]; !ok {
+			writeError(w, http.StatusUnauthorized, "Requires authentication.")
+			return
+		}
+
+		handler(w, r)
+	})
+}
+
+func token(r *http.Request) string {
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "token ")
+}
+
+// crud registers create, get, update and delete endpoints for a collection of objects with a numeric id
+func (s *Server) crud(mux *http.ServeMux, path string, collection string, idParam string) {
+	s.handle(mux, "GET "+path, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.list(collection))
+	})
+	s.handle(mux, "POST "+path, func(w http.ResponseWriter, r *http.Request) {
+		obj, err := decode(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if name, ok := obj["name"]; ok && s.nameTaken(collection, name, "") {
+			validationFailed(w, "name", "has already been taken")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, s.insert(collection, obj))
+	})
+	s.handle(mux, "GET "+path+"/{"+idParam+"}", s.getHandler(collection, idParam))
+	s.handle(mux, "PATCH "+path+"/{"+idParam+"}", s.updateHandler(collection, idParam))
+	s.handle(mux, "DELETE "+path+"/{"+idParam+"}", s.deleteHandler(collection, idParam))
+}
+
+func (s *Server) nameTaken(collection string, name interface{}, exceptID string) bool {
+	for _, obj := range s.list(collection) {
+		if obj["name"] == name && idString(obj["id"]) != exceptID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) getHandler(collection string, idParam string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if collection == "users" {
+			s.writeUser(w, r.PathValue(idParam))
+			return
+		}
+
+		obj, ok := s.get(collection, r.PathValue(idParam))
+		if !ok {
+			notFound(w)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, obj)
+	}
+}
+
+func (s *Server) updateHandler(collection string, idParam string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		obj, ok := s.get(collection, r.PathValue(idParam))
+		if !ok {
+			notFound(w)
+			return
+		}
+
+		changes, err := decode(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if name, ok := changes["name"]; ok && s.nameTaken(collection, name, r.PathValue(idParam)) {
+			validationFailed(w, "name", "has already been taken")
+			return
+		}
+
+		merge(obj, changes)
+		writeJSON(w, http.StatusOK, obj)
+	}
+}
+
+func (s *Server) deleteHandler(collection string, idParam string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := s.get(collection, r.PathValue(idParam)); !ok {
+			notFound(w)
+			return
+		}
+
+		delete(s.collections[collection], r.PathValue(idParam))
+		if collection == "users" {
+			for _, credentials := range []string{"credentials_email", "credentials_saml", "credentials_oidc", "credentials_ldap", "credentials_google"} {
+				delete(s.collections[credentials], r.PathValue(idParam))
+			}
+		}
+		noContent(w)
+	}
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r.ParseForm()
+	if r.Form.Get("client_id") != ClientID || r.Form.Get("client_secret") != ClientSecret {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	accessToken := newToken()
+	s.sessions[accessToken] = "production"
+
+	writeJSON(w, http.StatusOK, object{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   s.TokenTTL,
+	})
+}
+
+func (s *Server) logout(w http.ResponseWriter, r *http.Request) {
+	delete(s.sessions, token(r))
+	noContent(w)
+}
+
+func (s *Server) session(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, object{"workspace_id": s.sessions[token(r)]})
+}
+
+func (s *Server) updateSession(w http.ResponseWriter, r *http.Request) {
+	changes, err := decode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	workspace, _ := changes["workspace_id"].(string)
+	if workspace != "production" && workspace != "dev" {
+		validationFailed(w, "workspace_id", "must be production or dev")
+		return
+	}
+
+	s.sessions[token(r)] = workspace
+	writeJSON(w, http.StatusOK, object{"workspace_id": workspace})
+}
+
+func (s *Server) userCredential(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.PathValue("user_id") == "credential":
+		s.userForCredential(w, r)
+	case r.PathValue("credential_type") == "credentials_api3":
+		r.SetPathValue("credentials_api3_id", r.PathValue("credential_id"))
+		s.credentialsAPI3(w, r)
+	default:
+		notFound(w)
+	}
+}
+
+// userForCredential finds users by their email credentials, or by the external_user_id of embed credentials set
+// through the users' credentials_embed field
+func (s *Server) userForCredential(w http.ResponseWriter, r *http.Request) {
+	credentialID := r.PathValue("credential_id")
+
+	switch r.PathValue("credential_type") {
+	case "email":
+		for userID, credentials := range s.collections["credentials_email"] {
+			if email, _ := credentials["email"].(string); strings.EqualFold(email, credentialID) {
+				s.writeUser(w, userID)
+				return
+			}
+		}
+	case "embed":
+		for _, user := range s.list("users") {
+			embeds, _ := user["credentials_embed"].([]interface{})
+			for _, embed := range embeds {
+				if e, ok := embed.(map[string]interface{}); ok && idString(e["external_user_id"]) == credentialID {
+					s.writeUser(w, idString(user["id"]))
+					return
+				}
+			}
+		}
+	}
+
+	notFound(w)
+}
+
+// writeUser writes a user along with its email credentials, the way GET /users/{user_id} returns it
+func (s *Server) writeUser(w http.ResponseWriter, userID string) {
+	user, ok := s.get("users", userID)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	user = copyObject(user)
+	if credentials, ok := s.get("credentials_email", userID); ok {
+		user["credentials_email"] = credentials
+	}
+
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) credentialsEmail(w http.ResponseWriter, r *http.Request) {
+	s.getHandler("credentials_email", "user_id")(w, r)
+}
+
+func (s *Server) updateCredentialsEmail(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("user_id")
+	if _, ok := s.get("users", userID); !ok {
+		notFound(w)
+		return
+	}
+
+	changes, err := decode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	credentials, ok := s.get("credentials_email", userID)
+	if !ok {
+		if r.Method != http.MethodPost {
+			notFound(w)
+			return
+		}
+		credentials = object{"type": "email"}
+		s.put("credentials_email", userID, credentials)
+	} else if r.Method == http.MethodPost {
+		validationFailed(w, "email", "user already has email credentials")
+		return
+	}
+
+	merge(credentials, changes)
+	writeJSON(w, http.StatusOK, credentials)
+}
+
+// passwordReset sets a new password_reset_url on the email credentials of a user. When send is set, the email is
+// recorded in the password_reset_emails collection, keyed by user ID.
+func (s *Server) passwordReset(send bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := r.PathValue("user_id")
+
+		credentials, ok := s.get("credentials_email", userID)
+		if !ok {
+			notFound(w)
+			return
+		}
+
+		credentials["password_reset_url"] = s.URL + "/password/reset/" + newToken()
+		if send {
+			s.put("password_reset_emails", userID, object{
+				"email":              credentials["email"],
+				"password_reset_url": credentials["password_reset_url"],
+			})
+		}
+
+		writeJSON(w, http.StatusOK, credentials)
+	}
+}
+
+func (s *Server) deleteCredentialsEmail(w http.ResponseWriter, r *http.Request) {
+	s.deleteHandler("credentials_email", "user_id")(w, r)
+}
+
+func (s *Server) credentialsEmbeds(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.get("users", r.PathValue("user_id"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	embeds, _ := user["credentials_embed"].([]interface{})
+	if embeds == nil {
+		embeds = []interface{}{}
+	}
+
+	writeJSON(w, http.StatusOK, embeds)
+}
+
+func (s *Server) deleteCredentialsEmbed(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.get("users", r.PathValue("user_id"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	embeds, _ := user["credentials_embed"].([]interface{})
+	for i, embed := range embeds {
+		if e, ok := embed.(map[string]interface{}); ok && idString(e["id"]) == r.PathValue("credentials_embed_id") {
+			user["credentials_embed"] = append(embeds[:i:i], embeds[i+1:]...)
+			noContent(w)
+			return
+		}
+	}
+
+	notFound(w)
+}
+
+func (s *Server) createCredentialsAPI3(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("user_id")
+	if _, ok := s.get("users", userID); !ok {
+		notFound(w)
+		return
+	}
+
+	credentials := s.insert("credentials_api3", object{
+		"user_id":       userID,
+		"type":          "api3",
+		"client_id":     newToken()[:20],
+		"client_secret": newToken()[:24],
+	})
+
+	writeJSON(w, http.StatusOK, credentials)
+
+	// the secret is only returned when the credentials are created
+	delete(credentials, "client_secret")
+}
+
+func (s *Server) credentialsAPI3(w http.ResponseWriter, r *http.Request) {
+	credentials, ok := s.get("credentials_api3", r.PathValue("credentials_api3_id"))
+	if !ok || credentials["user_id"] != r.PathValue("user_id") {
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, credentials)
+}
+
+func (s *Server) deleteCredentialsAPI3(w http.ResponseWriter, r *http.Request) {
+	credentials, ok := s.get("credentials_api3", r.PathValue("credentials_api3_id"))
+	if !ok || credentials["user_id"] != r.PathValue("user_id") {
+		notFound(w)
+		return
+	}
+
+	delete(s.collections["credentials_api3"], r.PathValue("credentials_api3_id"))
+	noContent(w)
+}
+
+func (s *Server) userRoles(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.get("users", r.PathValue("user_id"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.lookup("roles", user["role_ids"]))
+}
+
+func (s *Server) setUserRoles(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.get("users", r.PathValue("user_id"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	ids, err := decodeIDs(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	roleIDs := []interface{}{}
+	for _, id := range ids {
+		if _, ok := s.get("roles", idString(id)); !ok {
+			validationFailed(w, "role_ids", fmt.Sprintf("role %d does not exist", id))
+			return
+		}
+		roleIDs = append(roleIDs, id)
+	}
+
+	user["role_ids"] = roleIDs
+	writeJSON(w, http.StatusOK, s.lookup("roles", roleIDs))
+}
+
+func (s *Server) roleGroups(w http.ResponseWriter, r *http.Request) {
+	role, ok := s.get("roles", r.PathValue("role_id"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.lookup("groups", role["group_ids"]))
+}
+
+func (s *Server) setRoleGroups(w http.ResponseWriter, r *http.Request) {
+	role, ok := s.get("roles", r.PathValue("role_id"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	ids, err := decodeIDs(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	groupIDs := []interface{}{}
+	for _, id := range ids {
+		if _, ok := s.get("groups", idString(id)); !ok {
+			validationFailed(w, "group_ids", fmt.Sprintf("group %d does not exist", id))
+			return
+		}
+		groupIDs = append(groupIDs, id)
+	}
+
+	role["group_ids"] = groupIDs
+	writeJSON(w, http.StatusOK, s.lookup("groups", groupIDs))
+}
+
+// groupMembers lists the users or groups included in a group. Like Looker, members deleted since they were added
+// are not listed.
+func (s *Server) groupMembers(collection string, field string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		group, ok := s.get("groups", r.PathValue("group_id"))
+		if !ok {
+			notFound(w)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, s.lookup(collection, group[field]))
+	}
+}
+
+func (s *Server) addGroupMember(collection string, field string, bodyField string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groupID := r.PathValue("group_id")
+		group, ok := s.get("groups", groupID)
+		if !ok {
+			notFound(w)
+			return
+		}
+
+		body, err := decode(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		memberID := idString(body[bodyField])
+		member, ok := s.get(collection, memberID)
+		if !ok {
+			notFound(w)
+			return
+		}
+		if collection == "groups" && memberID == groupID {
+			validationFailed(w, bodyField, "a group can't include itself")
+			return
+		}
+
+		group[field] = appendID(group[field], member["id"])
+		if collection == "users" {
+			member["group_ids"] = appendID(member["group_ids"], group["id"])
+		}
+
+		writeJSON(w, http.StatusOK, member)
+	}
+}
+
+func (s *Server) deleteGroupMember(field string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		group, ok := s.get("groups", r.PathValue("group_id"))
+		if !ok {
+			notFound(w)
+			return
+		}
+
+		memberID := r.PathValue("member_id")
+		ids, found := removeID(group[field], memberID)
+		if !found {
+			notFound(w)
+			return
+		}
+		group[field] = ids
+
+		if user, ok := s.get("users", memberID); ok && field == "user_ids" {
+			user["group_ids"], _ = removeID(user["group_ids"], idString(group["id"]))
+		}
+
+		noContent(w)
+	}
+}
+
+// hideValue blanks the value of an attribute value when its attribute is hidden, like Looker does
+func hideValue(value object, attribute object) object {
+	value = copyObject(value)
+	hidden, _ := attribute["value_is_hidden"].(bool)
+	value["value_is_hidden"] = hidden
+	if hidden {
+		value["value"] = ""
+	}
+	return value
+}
+
+func (s *Server) userAttributeGroupValues(w http.ResponseWriter, r *http.Request) {
+	attribute, ok := s.get("user_attributes", r.PathValue("user_attribute_id"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	values := []object{}
+	groupValues, _ := attribute["group_values"].([]interface{})
+	for _, value := range groupValues {
+		values = append(values, hideValue(value.(object), attribute))
+	}
+
+	writeJSON(w, http.StatusOK, values)
+}
+
+func (s *Server) setUserAttributeGroupValues(w http.ResponseWriter, r *http.Request) {
+	attribute, ok := s.get("user_attributes", r.PathValue("user_attribute_id"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	body := []object{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	groupValues := []interface{}{}
+	for i, value := range body {
+		value = normalize(value).(object)
+		if _, ok := s.get("groups", idString(value["group_id"])); !ok {
+			validationFailed(w, "group_id", fmt.Sprintf("group %s does not exist", idString(value["group_id"])))
+			return
+		}
+		groupValues = append(groupValues, object{
+			"id":                s.nextID + int64(i),
+			"group_id":          value["group_id"],
+			"user_attribute_id": attribute["id"],
+			"rank":              int64(i + 1),
+			"value":             value["value"],
+		})
+	}
+	s.nextID += int64(len(body))
+
+	attribute["group_values"] = groupValues
+	s.userAttributeGroupValues(w, r)
+}
+
+// userAttributeUserValues returns the values set on the user, then the values of the user's groups, then the
+// defaults of the remaining attributes, only the first of which is the value in effect
+func (s *Server) userAttributeUserValues(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("user_id")
+	user, ok := s.get("users", userID)
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	filter := map[string]bool{}
+	for _, id := range strings.Split(r.URL.Query().Get("user_attribute_ids"), ",") {
+		if id != "" {
+			filter[id] = true
+		}
+	}
+
+	userValues, _ := user["attribute_values"].(object)
+
+	values := []object{}
+	for _, attribute := range s.list("user_attributes") {
+		attributeID := idString(attribute["id"])
+		if len(filter) > 0 && !filter[attributeID] {
+			continue
+		}
+
+		value := object{"user_id": user["id"], "user_attribute_id": attribute["id"], "name": attribute["name"], "label": attribute["label"]}
+		if v, ok := userValues[attributeID]; ok {
+			value["source"] = "user"
+			value["value"] = v
+		} else if v, ok := attribute["default_value"]; ok {
+			value["source"] = "default"
+			value["value"] = v
+		} else {
+			continue
+		}
+
+		values = append(values, hideValue(value, attribute))
+	}
+
+	writeJSON(w, http.StatusOK, values)
+}
+
+func (s *Server) setUserAttributeUserValue(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.get("users", r.PathValue("user_id"))
+	if !ok {
+		notFound(w)
+		return
+	}
+	attribute, ok := s.get("user_attributes", r.PathValue("user_attribute_id"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	body, err := decode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userValues, _ := user["attribute_values"].(object)
+	if userValues == nil {
+		userValues = object{}
+		user["attribute_values"] = userValues
+	}
+	userValues[idString(attribute["id"])] = body["value"]
+
+	writeJSON(w, http.StatusOK, hideValue(object{
+		"user_id":           user["id"],
+		"user_attribute_id": attribute["id"],
+		"source":            "user",
+		"value":             body["value"],
+	}, attribute))
+}
+
+func (s *Server) deleteUserAttributeUserValue(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.get("users", r.PathValue("user_id"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	userValues, _ := user["attribute_values"].(object)
+	if _, ok := userValues[r.PathValue("user_attribute_id")]; !ok {
+		notFound(w)
+		return
+	}
+	delete(userValues, r.PathValue("user_attribute_id"))
+
+	noContent(w)
+}
+
+// Permissions are the permissions the fake lists on /permissions
+var Permissions = []string{
+	"access_data", "administer", "deploy", "develop", "explore", "save_content", "see_looks", "see_lookml",
+	"see_lookml_dashboards", "see_user_dashboards", "see_users", "use_sql_runner",
+}
+
+func (s *Server) permissions(w http.ResponseWriter, r *http.Request) {
+	permissions := []object{}
+	for _, permission := range Permissions {
+		permissions = append(permissions, object{"permission": permission})
+	}
+
+	writeJSON(w, http.StatusOK, permissions)
+}
+
+// lookup returns the objects of collection with the given ids, skipping ids that no longer exist
+func (s *Server) lookup(collection string, ids interface{}) []object {
+	objects := []object{}
+
+	list, _ := ids.([]interface{})
+	for _, id := range list {
+		if obj, ok := s.get(collection, idString(id)); ok {
+			objects = append(objects, obj)
+		}
+	}
+
+	return objects
+}
+
+func (s *Server) createSpaceHandler(w http.ResponseWriter, r *http.Request) {
+	obj, err := decode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, ok := s.get("spaces", idString(obj["parent_id"])); !ok {
+		validationFailed(w, "parent_id", "parent space does not exist")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.createSpace(obj))
+}
+
+func (s *Server) searchSpaces(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	parentID := r.URL.Query().Get("parent_id")
+
+	spaces := []object{}
+	for _, space := range s.list("spaces") {
+		if name != "" && !strings.EqualFold(space["name"].(string), name) {
+			continue
+		}
+		if parentID != "" && idString(space["parent_id"]) != parentID {
+			continue
+		}
+		spaces = append(spaces, space)
+	}
+
+	writeJSON(w, http.StatusOK, spaces)
+}
+
+func (s *Server) deleteSpace(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.get("spaces", r.PathValue("space_id"))
+	if !ok {
+		notFound(w)
+		return
+	}
+	if space["parent_id"] == nil {
+		validationFailed(w, "id", "root spaces can not be deleted")
+		return
+	}
+
+	delete(s.collections["content_metadata"], idString(space["content_metadata_id"]))
+	delete(s.collections["spaces"], r.PathValue("space_id"))
+	noContent(w)
+}
+
+func (s *Server) contentMetadataAccess(w http.ResponseWriter, r *http.Request) {
+	contentMetadataID := r.URL.Query().Get("content_metadata_id")
+
+	accesses := []object{}
+	for _, access := range s.list("content_metadata_access") {
+		if contentMetadataID == "" || idString(access["content_metadata_id"]) == contentMetadataID {
+			accesses = append(accesses, access)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, accesses)
+}
+
+func (s *Server) createContentMetadataAccess(w http.ResponseWriter, r *http.Request) {
+	obj, err := decode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, access := range s.list("content_metadata_access") {
+		if access["content_metadata_id"] == obj["content_metadata_id"] && access["group_id"] == obj["group_id"] {
+			validationFailed(w, "group_id", "Group already has access on content")
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, s.insert("content_metadata_access", obj))
+}
+
+// Dialects are the dialects the fake lists on /dialect_info, with the tests of their connections
+var Dialects = []map[string]interface{}{
+	{
+		"name": "postgres", "label": "PostgreSQL 9.5+", "installed": true, "default_port": "5432", "default_max_connections": "50",
+		"supported_options": map[string]bool{"additional_params": true, "host": true, "schema": true, "ssl": true, "timezone": true, "tmp_table": true, "username_required": true},
+		"connection_tests":  []string{"connect", "kill", "query", "database_timezone", "tmp_table", "pdt"},
+	},
+	{
+		"name": "redshift", "label": "Amazon Redshift", "installed": true, "default_port": "5439", "default_max_connections": "50",
+		"supported_options": map[string]bool{"additional_params": true, "host": true, "schema": true, "ssl": true, "timezone": true, "tmp_table": true, "username_required": true},
+		"connection_tests":  []string{"connect", "kill", "query", "database_timezone", "tmp_table", "pdt"},
+	},
+	{
+		"name": "bigquery_standard_sql", "label": "Google BigQuery Standard SQL", "installed": true, "default_port": "443", "default_max_connections": "50",
+		"supported_options": map[string]bool{"additional_params": true, "host": true, "oauth_credentials": true, "project_name": true, "timezone": true, "tmp_table": true},
+		"connection_tests":  []string{"connect", "query", "tmp_table", "pdt"},
+	},
+	{
+		"name": "snowflake", "label": "Snowflake", "installed": true, "default_port": "443", "default_max_connections": "50",
+		"supported_options": map[string]bool{"additional_params": true, "host": true, "oauth_credentials": true, "schema": true, "timezone": true, "tmp_table": true, "username_required": true},
+		"connection_tests":  []string{"connect", "kill", "query", "tmp_table", "pdt"},
+	},
+	{
+		"name": "mysql", "label": "MySQL", "installed": false, "default_port": "3306", "default_max_connections": "50",
+		"supported_options": map[string]bool{"additional_params": true, "host": true, "ssl": true, "timezone": true, "tmp_table": true, "username_required": true},
+		"connection_tests":  []string{"connect", "kill", "query", "database_timezone", "tmp_table"},
+	},
+}
+
+func (s *Server) dialectInfo(w http.ResponseWriter, r *http.Request) {
+	infos := []map[string]interface{}{}
+	for _, dialect := range Dialects {
+		info := map[string]interface{}{}
+		for k, v := range dialect {
+			if k != "connection_tests" {
+				info[k] = v
+			}
+		}
+		infos = append(infos, info)
+	}
+
+	writeJSON(w, http.StatusOK, infos)
+}
+
+func dialect(name string) map[string]interface{} {
+	for _, dialect := range Dialects {
+		if dialect["name"] == name {
+			return dialect
+		}
+	}
+	return nil
+}
+
+func (s *Server) createConnection(w http.ResponseWriter, r *http.Request) {
+	obj, err := decode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	name, _ := obj["name"].(string)
+	if name == "" {
+		validationFailed(w, "name", "can't be blank")
+		return
+	}
+	if _, ok := s.get("connections", name); ok {
+		validationFailed(w, "name", "has already been taken")
+		return
+	}
+
+	s.put("connections", name, obj)
+	writeJSON(w, http.StatusOK, connectionResponse(obj))
+}
+
+func (s *Server) connection(w http.ResponseWriter, r *http.Request) {
+	obj, ok := s.get("connections", r.PathValue("connection_name"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, connectionResponse(obj))
+}
+
+func (s *Server) updateConnection(w http.ResponseWriter, r *http.Request) {
+	obj, ok := s.get("connections", r.PathValue("connection_name"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	changes, err := decode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	merge(obj, changes)
+	obj["name"] = r.PathValue("connection_name")
+	writeJSON(w, http.StatusOK, connectionResponse(obj))
+}
+
+// the API never returns connection passwords, and details the dialect
+func connectionResponse(obj object) object {
+	c := copyObject(obj)
+	delete(c, "password")
+	if dialect := dialect(fmt.Sprint(obj["dialect_name"])); dialect != nil {
+		c["dialect"] = map[string]interface{}{"name": dialect["name"], "label": dialect["label"], "connection_tests": dialect["connection_tests"]}
+	}
+	return c
+}
+
+// testConnection runs the requested tests, or all the tests of the dialect. They all pass, unless the password is
+// WrongPassword: connecting fails then and the other tests are skipped.
+func (s *Server) testConnection(w http.ResponseWriter, r *http.Request) {
+	obj, ok := s.get("connections", r.PathValue("connection_name"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	tests := []string{}
+	if param := r.URL.Query().Get("tests"); param != "" {
+		tests = strings.Split(param, ",")
+	} else if dialect := dialect(fmt.Sprint(obj["dialect_name"])); dialect != nil {
+		tests = dialect["connection_tests"].([]string)
+	}
+
+	results := []object{}
+	for _, test := range tests {
+		result := object{"name": test, "status": "success", "message": "Can " + test}
+		if obj["password"] == WrongPassword {
+			result["status"], result["message"] = "skipped", "Can't run without a connection"
+			if test == "connect" {
+				result["status"], result["message"] = "error", "Cannot connect: password authentication failed"
+			}
+		}
+		if test == "connect" {
+			result["connection_string"] = fmt.Sprintf("jdbc:%s://%v:%v/%v", obj["dialect_name"], obj["host"], obj["port"], obj["database"])
+		}
+		results = append(results, result)
+	}
+
+	writeJSON(w, http.StatusOK, results)
+}
+
+// projects only exist in the dev workspace until they are deployed, which the fake does not support
+func (s *Server) devProject(w http.ResponseWriter, r *http.Request) (object, bool) {
+	if s.sessions[token(r)] != "dev" {
+		notFound(w)
+		return nil, false
+	}
+
+	project, ok := s.get("projects", r.PathValue("project_id"))
+	if !ok {
+		notFound(w)
+		return nil, false
+	}
+
+	return project, true
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	if s.sessions[token(r)] != "dev" {
+		validationFailed(w, "workspace_id", "projects can only be created in the dev workspace")
+		return
+	}
+
+	obj, err := decode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	name, _ := obj["name"].(string)
+	if _, ok := s.get("projects", name); ok || name == "" {
+		validationFailed(w, "name", "is invalid or already taken")
+		return
+	}
+
+	// the settings Looker gives projects created without them
+	for key, value := range (object{"pull_request_mode": "off", "validation_required": false, "allow_warnings": true, "git_release_mgmt_enabled": false}) {
+		if _, ok := obj[key]; !ok {
+			obj[key] = value
+		}
+	}
+
+	obj["id"] = name
+	s.put("projects", name, obj)
+	writeJSON(w, http.StatusOK, projectResponse(obj))
+}
+
+// the API never returns git passwords
+func projectResponse(obj object) object {
+	c := copyObject(obj)
+	delete(c, "git_password")
+	return c
+}
+
+func (s *Server) project(w http.ResponseWriter, r *http.Request) {
+	if project, ok := s.devProject(w, r); ok {
+		writeJSON(w, http.StatusOK, projectResponse(project))
+	}
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	project, ok := s.devProject(w, r)
+	if !ok {
+		return
+	}
+
+	changes, err := decode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if name, ok := changes["name"].(string); ok && name != project["id"] {
+		delete(s.collections["projects"], r.PathValue("project_id"))
+		if key, ok := s.collections["git_deploy_keys"][r.PathValue("project_id")]; ok {
+			s.put("git_deploy_keys", name, key)
+			delete(s.collections["git_deploy_keys"], r.PathValue("project_id"))
+		}
+		project["id"] = name
+		s.put("projects", name, project)
+	}
+
+	merge(project, changes)
+	writeJSON(w, http.StatusOK, projectResponse(project))
+}
+
+func (s *Server) gitDeployKey(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.devProject(w, r); !ok {
+		return
+	}
+
+	key, ok := s.get("git_deploy_keys", r.PathValue("project_id"))
+	if !ok {
+		notFound(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprint(w, key["key"])
+}
+
+func (s *Server) createGitDeployKey(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.devProject(w, r); !ok {
+		return
+	}
+
+	// a key blob starts with the length prefixed key type, which makes it start with "AAAAB3NzaC1yc2E" once encoded
+	blob := append([]byte("\x00\x00\x00\x07ssh-rsa"), newToken()...)
+	key := "ssh-rsa " + base64.StdEncoding.EncodeToString(blob) + " looker@" + r.PathValue("project_id")
+	s.put("git_deploy_keys", r.PathValue("project_id"), object{"key": key})
+
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprint(w, key)
+}
+
+var gitConnectionTests = []object{
+	{"id": "git_remote_url", "description": "Checking that the remote URL is set"},
+	{"id": "git_authentication", "description": "Checking that Looker can authenticate with the remote"},
+}
+
+func (s *Server) gitConnectionTests(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.devProject(w, r); ok {
+		writeJSON(w, http.StatusOK, gitConnectionTests)
+	}
+}
+
+// runGitConnectionTest fails authenticating with an SSH remote when the project has no deploy key
+func (s *Server) runGitConnectionTest(w http.ResponseWriter, r *http.Request) {
+	project, ok := s.devProject(w, r)
+	if !ok {
+		return
+	}
+
+	remote, _ := project["git_remote_url"].(string)
+	result := object{"id": r.PathValue("test_id"), "status": "pass"}
+
+	switch r.PathValue("test_id") {
+	case "git_remote_url":
+		if remote == "" {
+			result["status"], result["message"] = "fail", "The project has no git remote"
+		}
+	case "git_authentication":
+		if _, ok := s.get("git_deploy_keys", r.PathValue("project_id")); !ok && strings.HasPrefix(remote, "git@") {
+			result["status"], result["message"] = "fail", "Permission denied (publickey)"
+		}
+	default:
+		notFound(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// authConfigSecrets are the fields of the authentication configurations the API never returns
+var authConfigSecrets = []string{"secret", "auth_password", "test_ldap_password"}
+
+// writeAuthConfig writes a SAML, OIDC or LDAP configuration without its secrets
+func (s *Server) writeAuthConfig(w http.ResponseWriter, name string) {
+	config, ok := s.get("auth_configs", name)
+	if !ok {
+		config = object{"enabled": false, "groups_with_role_ids": []interface{}{}, "user_attributes_with_ids": []interface{}{}}
+		s.put("auth_configs", name, config)
+	}
+
+	config = copyObject(config)
+	if name == "ldap" {
+		config["has_auth_password"] = config["auth_password"] != nil && config["auth_password"] != ""
+	}
+	for _, secret := range authConfigSecrets {
+		delete(config, secret)
+	}
+
+	writeJSON(w, http.StatusOK, config)
+}
+
+func (s *Server) authConfig(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.writeAuthConfig(w, name)
+	}
+}
+
+func (s *Server) updateAuthConfig(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		changes, err := decode(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		config, ok := s.get("auth_configs", name)
+		if !ok {
+			config = object{}
+			s.put("auth_configs", name, config)
+		}
+		merge(config, changes)
+
+		s.writeAuthConfig(w, name)
+	}
+}
+
+// testLdapConnection fails for hosts ending in .invalid, and for WrongPassword, given or saved
+func (s *Server) testLdapConnection(w http.ResponseWriter, r *http.Request) {
+	config, err := decode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	password := config["auth_password"]
+	if password == nil {
+		if saved, ok := s.get("auth_configs", "ldap"); ok {
+			password = saved["auth_password"]
+		}
+	}
+
+	host := fmt.Sprint(config["connection_host"])
+	switch {
+	case strings.HasSuffix(host, ".invalid"):
+		writeJSON(w, http.StatusOK, object{
+			"status":  "error",
+			"message": "Cannot connect to " + host,
+			"issues":  []interface{}{object{"severity": "error", "message": "getaddrinfo: Name or service not known"}},
+		})
+	case password == WrongPassword:
+		writeJSON(w, http.StatusOK, object{
+			"status":  "error",
+			"message": "Cannot bind as " + fmt.Sprint(config["auth_username"]),
+			"issues":  []interface{}{object{"severity": "error", "message": "Invalid credentials"}},
+		})
+	default:
+		writeJSON(w, http.StatusOK, object{"status": "success", "message": "Connected to " + host, "issues": []interface{}{}})
+	}
+}
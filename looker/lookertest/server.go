@@ -0,0 +1,359 @@
+// Package lookertest provides an in-process fake of the Looker 3.0 API, covering the endpoints the provider uses,
+// so the provider can be exercised without a Looker instance.
+package lookertest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+const (
+	// ClientID and ClientSecret are the API3 credentials the fake accepts
+	ClientID     = "lookertest-client-id"
+	ClientSecret = "lookertest-client-secret"
+
+	// WrongPassword is the connection and LDAP password the fake's connection tests reject
+	WrongPassword = "wrong"
+
+	basePath = "/api/3.0"
+)
+
+type object map[string]interface{}
+
+// Server is a fake Looker API backed by in-memory state. All state is lost when the server is closed.
+type Server struct {
+	*httptest.Server
+
+	// TokenTTL is the expires_in, in seconds, of the access tokens handed out by /login
+	TokenTTL int64
+
+	mu     sync.Mutex
+	nextID int64
+
+	// access token -> workspace of its session
+	sessions map[string]string
+
+	collections map[string]map[string]object
+}
+
+// NewServer starts a fake Looker API. It is seeded with the objects every Looker instance has: the Admin role,
+// permission set and the All model set, and the Shared, Users and Embed Groups root spaces.
+func NewServer() *Server {
+	s := &Server{
+		TokenTTL:    3600,
+		nextID:      1,
+		sessions:    map[string]string{},
+		collections: map[string]map[string]object{},
+	}
+
+	s.seed()
+	s.Server = httptest.NewServer(s.routes())
+
+	return s
+}
+
+// BaseURL returns the value of the provider's base_url argument pointing at this server
+func (s *Server) BaseURL() string {
+	return s.URL
+}
+
+// ProviderConfig returns a provider block configured for this server
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "looker" {
+  base_url      = %q
+  client_id     = %q
+  client_secret = %q
+}
+`, s.BaseURL(), ClientID, ClientSecret)
+}
+
+// ExpireTokens invalidates every access token, so the next request of every client is rejected with a 401
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions = map[string]string{}
+}
+
+// Get returns a copy of an object stored by the fake, e.g. Get("users", "12"). It is meant for checks that the
+// provider changed the remote state the way it should.
+func (s *Server) Get(collection string, id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.collections[collection][id]
+	if !ok {
+		return nil, false
+	}
+
+	return copyObject(obj), true
+}
+
+// Update changes fields of an object behind the provider's back, e.g. to simulate a setting changed in the admin UI
+func (s *Server) Update(collection string, id string, fields map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if obj, ok := s.get(collection, id); ok {
+		merge(obj, copyObject(fields))
+	}
+}
+
+// Delete removes an object behind the provider's back, e.g. to simulate a user deleted in the admin UI
+func (s *Server) Delete(collection string, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.collections[collection], id)
+}
+
+// LinkCredentials links credentials of a type, e.g. "saml", to a user, the way the user's first login through an
+// identity provider does. Embed credentials get an id and are added to the user's credentials_embed.
+func (s *Server) LinkCredentials(userID string, credentialType string, fields map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credentials := copyObject(fields)
+	credentials["type"] = credentialType
+
+	if credentialType != "embed" {
+		s.put("credentials_"+credentialType, userID, credentials)
+		return
+	}
+
+	user, ok := s.get("users", userID)
+	if !ok {
+		return
+	}
+
+	credentials["id"] = s.nextID
+	s.nextID++
+	embeds, _ := user["credentials_embed"].([]interface{})
+	user["credentials_embed"] = append(embeds, map[string]interface{}(credentials))
+}
+
+func (s *Server) seed() {
+	permissionSet := s.insert("permission_sets", object{"name": "Admin", "permissions": []interface{}{"administer"}, "built_in": true})
+	modelSet := s.insert("model_sets", object{"name": "All", "models": []interface{}{}, "all_access": true, "built_in": true})
+	s.insert("roles", object{"name": "Admin", "permission_set_id": permissionSet["id"], "model_set_id": modelSet["id"]})
+
+	for _, name := range []string{"Shared", "Users", "Embed Groups"} {
+		s.createSpace(object{"name": name, "parent_id": nil})
+	}
+}
+
+// insert stores obj under a new numeric id
+func (s *Server) insert(collection string, obj object) object {
+	id := s.nextID
+	s.nextID++
+
+	obj["id"] = id
+	s.put(collection, strconv.FormatInt(id, 10), obj)
+
+	return obj
+}
+
+func (s *Server) put(collection string, key string, obj object) {
+	if s.collections[collection] == nil {
+		s.collections[collection] = map[string]object{}
+	}
+	s.collections[collection][key] = obj
+}
+
+func (s *Server) get(collection string, key string) (object, bool) {
+	obj, ok := s.collections[collection][key]
+	return obj, ok
+}
+
+// list returns the objects of a collection ordered by key
+func (s *Server) list(collection string) []object {
+	keys := []string{}
+	for key := range s.collections[collection] {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.ParseInt(keys[i], 10, 64)
+		b, errB := strconv.ParseInt(keys[j], 10, 64)
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+
+	objects := []object{}
+	for _, key := range keys {
+		objects = append(objects, s.collections[collection][key])
+	}
+
+	return objects
+}
+
+func (s *Server) createSpace(obj object) object {
+	space := s.insert("spaces", obj)
+
+	parentMetadataID := interface{}(nil)
+	if parent, ok := s.get("spaces", idString(obj["parent_id"])); ok {
+		parentMetadataID = parent["content_metadata_id"]
+	}
+
+	metadata := s.insert("content_metadata", object{
+		"name":      obj["name"],
+		"space_id":  space["id"],
+		"parent_id": parentMetadataID,
+		"inherits":  parentMetadataID != nil,
+	})
+	space["content_metadata_id"] = metadata["id"]
+
+	return space
+}
+
+func newToken() string {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func idString(v interface{}) string {
+	switch id := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return id
+	case int64:
+		return strconv.FormatInt(id, 10)
+	case json.Number:
+		return id.String()
+	case float64:
+		return strconv.FormatInt(int64(id), 10)
+	}
+	return fmt.Sprint(v)
+}
+
+// appendID adds id to a list of ids unless it is already in it
+func appendID(ids interface{}, id interface{}) []interface{} {
+	list, _ := ids.([]interface{})
+	for _, existing := range list {
+		if idString(existing) == idString(id) {
+			return list
+		}
+	}
+	return append(list, id)
+}
+
+// removeID removes id from a list of ids, reporting whether it was in it
+func removeID(ids interface{}, id string) ([]interface{}, bool) {
+	list, _ := ids.([]interface{})
+	kept := []interface{}{}
+	found := false
+	for _, existing := range list {
+		if idString(existing) == id {
+			found = true
+			continue
+		}
+		kept = append(kept, existing)
+	}
+	return kept, found
+}
+
+func copyObject(obj object) object {
+	b, _ := json.Marshal(obj)
+
+	var c object
+	json.Unmarshal(b, &c)
+
+	return c
+}
+
+func merge(obj object, changes object) {
+	for k, v := range changes {
+		if k == "id" {
+			continue
+		}
+		obj[k] = v
+	}
+}
+
+func decode(r *http.Request) (object, error) {
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+
+	obj := object{}
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return normalize(obj).(object), nil
+}
+
+func decodeIDs(r *http.Request) ([]int64, error) {
+	ids := []int64{}
+	if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// normalize turns integral json.Numbers into int64 so ids compare equal to the ones generated by the server
+func normalize(v interface{}) interface{} {
+	switch value := v.(type) {
+	case object:
+		for k, field := range value {
+			value[k] = normalize(field)
+		}
+		return value
+	case map[string]interface{}:
+		return normalize(object(value))
+	case []interface{}:
+		for i := range value {
+			value[i] = normalize(value[i])
+		}
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		f, _ := value.Float64()
+		return f
+	}
+	return v
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, object{
+		"message":           message,
+		"documentation_url": "http://docs.looker.com/",
+	})
+}
+
+func notFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "Not found")
+}
+
+func validationFailed(w http.ResponseWriter, field string, message string) {
+	writeJSON(w, http.StatusUnprocessableEntity, object{
+		"message":           "Validation Failed",
+		"documentation_url": "http://docs.looker.com/",
+		"errors": []object{
+			{"field": field, "code": "invalid", "message": message},
+		},
+	})
+}
+
+func noContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,183 @@
+package looker
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/billtrust/looker-go-sdk/client/role"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The permissions each API version knows, one per line. Instances may have permissions added in later Looker
+// releases, which live validation picks up, so a permission missing from these lists is only a warning unless
+// validation is strict.
+//
+//go:embed permissions/*.txt
+var permissionLists embed.FS
+
+// permissionValidator holds the permissions permission sets are checked against at plan time
+type permissionValidator struct {
+	apiVersion string
+
+	// when set the permissions are listed by the API instead of read from the embedded list
+	live bool
+	role roleAPI
+	// when set unknown permissions fail the plan, otherwise they're warnings on apply
+	strict bool
+
+	once        sync.Once
+	permissions []string
+	err         error
+}
+
+// newPermissionValidator returns a validator for the permissions of apiVersion, or of the instance when live is set.
+// The instance's own list is always up to date, so live validation is strict.
+func newPermissionValidator(apiVersion string, live bool, strict bool, role roleAPI) *permissionValidator {
+	return &permissionValidator{
+		apiVersion: apiVersion,
+		live:       live,
+		role:       role,
+		strict:     strict || live,
+	}
+}
+
+func (v *permissionValidator) knownPermissions() ([]string, error) {
+	v.once.Do(func() {
+		if v.live {
+			v.permissions, v.err = getAllPermissions(v.role)
+		} else {
+			v.permissions, v.err = getEmbeddedPermissions(v.apiVersion)
+		}
+	})
+
+	return v.permissions, v.err
+}
+
+// permissionsPath points diagnostics at the permissions of a permission set
+var permissionsPath = cty.GetAttrPath("permissions")
+
+// validate returns an error naming every permission that doesn't exist when validation is strict. The error carries
+// the path of the permissions, so Terraform shows it on the attribute.
+func (v *permissionValidator) validate(permissions []string) error {
+	if !v.strict {
+		return nil
+	}
+
+	problems, err := v.unknownPermissions(permissions)
+	if err != nil {
+		return permissionsPath.NewError(err)
+	}
+	if len(problems) > 0 {
+		return permissionsPath.NewErrorf("%s", strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+// warnings returns a warning naming every permission that doesn't exist when validation isn't strict
+func (v *permissionValidator) warnings(permissions []string) diag.Diagnostics {
+	if v.strict {
+		return nil
+	}
+
+	problems, err := v.unknownPermissions(permissions)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Warning,
+			Summary:       "The permissions were not validated",
+			Detail:        err.Error(),
+			AttributePath: permissionsPath,
+		}}
+	}
+	if len(problems) > 0 {
+		return diag.Diagnostics{{
+			Severity:      diag.Warning,
+			Summary:       "Unknown permissions",
+			AttributePath: permissionsPath,
+			Detail:        strings.Join(problems, "\n") + "\n\nThey may have been added in a Looker release newer than the provider's list. Set live_permission_validation to check them against the instance, or strict_permission_validation to fail the plan instead.",
+		}}
+	}
+
+	return nil
+}
+
+// unknownPermissions describes every permission that doesn't exist, with the closest known ones
+func (v *permissionValidator) unknownPermissions(permissions []string) ([]string, error) {
+	known, err := v.knownPermissions()
+	if err != nil {
+		return nil, fmt.Errorf("Can't list the permissions to validate the permission set against: %s", err)
+	}
+
+	source := "Looker API " + v.apiVersion
+	if v.live {
+		source = "this Looker instance"
+	}
+
+	sort.Strings(permissions)
+
+	problems := []string{}
+	for _, permission := range permissions {
+		if !containsString(known, permission) {
+			problems = append(problems, fmt.Sprintf("%q is not a permission of %s%s", permission, source, didYouMean(permission, known)))
+		}
+	}
+
+	return problems, nil
+}
+
+func getEmbeddedPermissions(apiVersion string) ([]string, error) {
+	b, err := permissionLists.ReadFile("permissions/" + apiVersion + ".txt")
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(string(b)), nil
+}
+
+func getAllPermissions(client roleAPI) ([]string, error) {
+	result, err := client.AllPermissions(role.NewAllPermissionsParams())
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := []string{}
+	for _, permission := range result.Payload {
+		permissions = append(permissions, permission.Permission)
+	}
+
+	return permissions, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func resourcePermissionSetValidatePermissions(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("permissions") {
+		return nil
+	}
+
+	// the provider isn't configured yet when the diff is computed for validation only
+	client, ok := m.(*Client)
+	if !ok || client == nil {
+		return nil
+	}
+
+	permissions := []string{}
+	for _, permission := range d.Get("permissions").(*schema.Set).List() {
+		permissions = append(permissions, permission.(string))
+	}
+
+	return client.permissions.validate(permissions)
+}
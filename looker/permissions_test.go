@@ -0,0 +1,43 @@
+package looker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestPermissionValidator(t *testing.T) {
+	permissions := []string{"access_data", "acess_data"}
+
+	for _, apiVersion := range []string{apiVersion30, apiVersion40} {
+		t.Run(apiVersion, func(t *testing.T) {
+			lenient := newPermissionValidator(apiVersion, false, false, nil)
+			if err := lenient.validate(permissions); err != nil {
+				t.Errorf("lenient validation failed: %s", err)
+			}
+			warnings := lenient.warnings(permissions)
+			if len(warnings) != 1 || warnings[0].Severity != diag.Warning || !strings.Contains(warnings[0].Detail, `"acess_data" is not a permission`) {
+				t.Errorf("expected a warning for acess_data, got %v", warnings)
+			} else if !warnings[0].AttributePath.Equals(cty.GetAttrPath("permissions")) {
+				t.Errorf("expected the warning on permissions, got %#v", warnings[0].AttributePath)
+			}
+			if warnings := lenient.warnings([]string{"access_data"}); len(warnings) != 0 {
+				t.Errorf("expected no warning for known permissions, got %v", warnings)
+			}
+
+			strict := newPermissionValidator(apiVersion, false, true, nil)
+			err := strict.validate(permissions)
+			if err == nil || !strings.Contains(err.Error(), `"acess_data" is not a permission of Looker API `+apiVersion) || !strings.Contains(err.Error(), "access_data") {
+				t.Errorf("expected strict validation to fail for acess_data, got %v", err)
+			}
+			if pathErr, ok := err.(cty.PathError); !ok || !pathErr.Path.Equals(cty.GetAttrPath("permissions")) {
+				t.Errorf("expected the error on permissions, got %#v", err)
+			}
+			if warnings := strict.warnings(permissions); len(warnings) != 0 {
+				t.Errorf("expected no warning under strict validation, got %v", warnings)
+			}
+		})
+	}
+}
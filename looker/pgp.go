@@ -0,0 +1,104 @@
+package looker
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+const keybasePrefix = "keybase:"
+
+var (
+	// keybaseLookupURL returns the public keys of keybase users
+	keybaseLookupURL = "https://keybase.io/_/api/1.0/user/lookup.json"
+	keybaseClient    = &http.Client{Timeout: 30 * time.Second}
+)
+
+// getPGPEntity parses a pgp_key argument: a base64 encoded public key, or "keybase:<username>" for the public key of a
+// keybase user
+func getPGPEntity(pgpKey string) (*openpgp.Entity, error) {
+	if strings.HasPrefix(pgpKey, keybasePrefix) {
+		return getKeybaseEntity(strings.TrimPrefix(pgpKey, keybasePrefix))
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(pgpKey))
+	if err != nil {
+		return nil, fmt.Errorf("pgp_key isn't a base64 encoded public key: %s", err)
+	}
+
+	entities, err := openpgp.ReadKeyRing(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("pgp_key isn't a public key: %s", err)
+	}
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("pgp_key should hold one public key, it holds %d", len(entities))
+	}
+
+	return entities[0], nil
+}
+
+// getKeybaseEntity fetches the primary public key of a keybase user
+func getKeybaseEntity(username string) (*openpgp.Entity, error) {
+	resp, err := keybaseClient.Get(keybaseLookupURL + "?fields=public_keys&usernames=" + url.QueryEscape(username))
+	if err != nil {
+		return nil, fmt.Errorf("Can't fetch the public key of keybase user %s: %s", username, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Can't fetch the public key of keybase user %s: keybase responded with %s", username, resp.Status)
+	}
+
+	var result struct {
+		Them []struct {
+			PublicKeys struct {
+				Primary struct {
+					Bundle string `json:"bundle"`
+				} `json:"primary"`
+			} `json:"public_keys"`
+		} `json:"them"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("Can't fetch the public key of keybase user %s: %s", username, err)
+	}
+	if len(result.Them) != 1 || result.Them[0].PublicKeys.Primary.Bundle == "" {
+		return nil, fmt.Errorf("Keybase user %s has no public key", username)
+	}
+
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(result.Them[0].PublicKeys.Primary.Bundle))
+	if err != nil {
+		return nil, fmt.Errorf("Can't read the public key of keybase user %s: %s", username, err)
+	}
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("The primary key bundle of keybase user %s should hold one public key, it holds %d", username, len(entities))
+	}
+
+	return entities[0], nil
+}
+
+// encryptWithPGP encrypts value for entity, returning the base64 encoded message, which decrypts with
+// `base64 -d | gpg -d`, and the fingerprint of the key
+func encryptWithPGP(entity *openpgp.Entity, value string) (string, string, error) {
+	buf := &bytes.Buffer{}
+
+	w, err := openpgp.Encrypt(buf, []*openpgp.Entity{entity}, nil, nil, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("Can't encrypt with pgp_key: %s", err)
+	}
+	if _, err := w.Write([]byte(value)); err != nil {
+		return "", "", fmt.Errorf("Can't encrypt with pgp_key: %s", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", "", fmt.Errorf("Can't encrypt with pgp_key: %s", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), hex.EncodeToString(entity.PrimaryKey.Fingerprint), nil
+}
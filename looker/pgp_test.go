@@ -0,0 +1,151 @@
+package looker
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+func newTestPGPEntity(t *testing.T) *openpgp.Entity {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("Terraform", "", "terraform@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entity
+}
+
+// armoredPublicKeys returns the public keys of entities as one armored bundle, as keybase returns them
+func armoredPublicKeys(t *testing.T, entities ...*openpgp.Entity) string {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entity := range entities {
+		if err := entity.Serialize(w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestGetPGPEntity(t *testing.T) {
+	entity := newTestPGPEntity(t)
+
+	buf := &bytes.Buffer{}
+	if err := entity.Serialize(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := getPGPEntity(base64.StdEncoding.EncodeToString(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result.PrimaryKey.Fingerprint, entity.PrimaryKey.Fingerprint) {
+		t.Errorf("got key %X, want %X", result.PrimaryKey.Fingerprint, entity.PrimaryKey.Fingerprint)
+	}
+
+	if _, err := getPGPEntity("not a key"); err == nil {
+		t.Error("expected an error for a value that isn't base64")
+	}
+	if _, err := getPGPEntity(base64.StdEncoding.EncodeToString([]byte("not a key"))); err == nil {
+		t.Error("expected an error for a value that isn't a key")
+	}
+}
+
+func TestEncryptWithPGP(t *testing.T) {
+	entity := newTestPGPEntity(t)
+
+	encrypted, fingerprint, err := encryptWithPGP(entity, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := hex.EncodeToString(entity.PrimaryKey.Fingerprint); fingerprint != want {
+		t.Errorf("got fingerprint %s, want %s", fingerprint, want)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := openpgp.ReadMessage(bytes.NewReader(raw), openpgp.EntityList{entity}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := ioutil.ReadAll(message.UnverifiedBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decrypted) != "secret" {
+		t.Errorf("decrypted %q, want %q", decrypted, "secret")
+	}
+}
+
+func TestGetKeybaseEntity(t *testing.T) {
+	first := newTestPGPEntity(t)
+	second := newTestPGPEntity(t)
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"one key", http.StatusOK, keybaseLookupResponse(armoredPublicKeys(t, first)), ""},
+		{"two keys", http.StatusOK, keybaseLookupResponse(armoredPublicKeys(t, first, second)), "should hold one public key, it holds 2"},
+		{"no key", http.StatusOK, `{"them": [{"public_keys": {}}]}`, "has no public key"},
+		{"unknown user", http.StatusOK, `{"them": []}`, "has no public key"},
+		{"error status", http.StatusInternalServerError, "<html>error</html>", "500 Internal Server Error"},
+		{"invalid bundle", http.StatusOK, keybaseLookupResponse("not a key"), "Can't read the public key"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("usernames"); got != "jane" {
+					t.Errorf("looked up %q, want jane", got)
+				}
+				w.WriteHeader(test.status)
+				fmt.Fprint(w, test.body)
+			}))
+			defer server.Close()
+
+			defer func(url string) { keybaseLookupURL = url }(keybaseLookupURL)
+			keybaseLookupURL = server.URL
+
+			entity, err := getPGPEntity(keybasePrefix + "jane")
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(entity.PrimaryKey.Fingerprint, first.PrimaryKey.Fingerprint) {
+					t.Errorf("got key %X, want %X", entity.PrimaryKey.Fingerprint, first.PrimaryKey.Fingerprint)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func keybaseLookupResponse(bundle string) string {
+	return fmt.Sprintf(`{"them": [{"public_keys": {"primary": {"bundle": %q}}}]}`, bundle)
+}
@@ -0,0 +1,90 @@
+package looker
+
+import (
+	"net/http"
+
+	"github.com/billtrust/looker-go-sdk/client/project"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+// lookerProject is a project as the provider exchanges it with the API. models.Project lacks the fields added to the
+// API after the SDK was generated, and omits false booleans, which would leave them at their previous value on PATCH.
+// The booleans are pointers so the ones left out of the configuration aren't sent at all.
+type lookerProject struct {
+	*models.Project
+	ValidationRequired    *bool `json:"validation_required,omitempty"`
+	AllowWarnings         *bool `json:"allow_warnings,omitempty"`
+	GitReleaseMgmtEnabled *bool `json:"git_release_mgmt_enabled,omitempty"`
+}
+
+// projectGitDetails are the git fields of a project. Empty values are sent, so they can be cleared, except for the
+// password which is only sent when set, as it's never returned.
+type projectGitDetails struct {
+	GitRemoteURL             string  `json:"git_remote_url"`
+	GitUsername              string  `json:"git_username"`
+	GitPassword              *string `json:"git_password,omitempty"`
+	GitUsernameUserAttribute string  `json:"git_username_user_attribute"`
+	GitPasswordUserAttribute string  `json:"git_password_user_attribute"`
+	GitServiceName           string  `json:"git_service_name,omitempty"`
+}
+
+// projectClient reads and writes projects as lookerProject, and leaves the other operations to the SDK. Projects
+// are only all visible in the dev workspace, so the callers switch to it.
+type projectClient struct {
+	*project.Client
+	transport runtime.ClientTransport
+}
+
+func (c *projectClient) getProject(projectID string) (*lookerProject, error) {
+	return c.submit("project", "GET", "/projects/{project_id}", projectID, nil, &project.ProjectReader{})
+}
+
+func (c *projectClient) createProject(body *lookerProject) (*lookerProject, error) {
+	return c.submit("create_project", "POST", "/projects", "", body, &project.CreateProjectReader{})
+}
+
+// updateProject sends body, a *lookerProject or *projectGitDetails, so the git details and the other settings of a
+// project can be managed separately
+func (c *projectClient) updateProject(projectID string, body interface{}) (*lookerProject, error) {
+	return c.submit("update_project", "PATCH", "/projects/{project_id}", projectID, body, &project.UpdateProjectReader{})
+}
+
+// submit runs a project operation, decoding errors with the SDK's reader so they keep their types
+func (c *projectClient) submit(id string, method string, path string, projectID string, body interface{}, errorReader runtime.ClientResponseReader) (*lookerProject, error) {
+	result, err := c.transport.Submit(&runtime.ClientOperation{
+		ID:                 id,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if projectID != "" {
+				if err := r.SetPathParam("project_id", projectID); err != nil {
+					return err
+				}
+			}
+			if body != nil {
+				return r.SetBodyParam(body)
+			}
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() != http.StatusOK {
+				return errorReader.ReadResponse(response, consumer)
+			}
+
+			result := &lookerProject{Project: &models.Project{}}
+			if err := consumer.Consume(response.Body(), result); err != nil {
+				return nil, err
+			}
+			return result, nil
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*lookerProject), nil
+}
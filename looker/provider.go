@@ -1,20 +1,18 @@
 package looker
 
 import (
-	"log"
-
-	apiclient "github.com/billtrust/looker-go-sdk/client"
-	"github.com/billtrust/looker-go-sdk/client/api_auth"
-
-	"github.com/go-openapi/strfmt"
+	"context"
+	"strings"
+	"time"
 
+	"github.com/go-openapi/runtime"
 	httptransport "github.com/go-openapi/runtime/client"
-	"github.com/hashicorp/terraform/terraform"
-
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-func Provider() terraform.ResourceProvider {
+func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"client_id": {
@@ -33,57 +31,138 @@ func Provider() terraform.ResourceProvider {
 				Type:        schema.TypeString,
 				Required:    true,
 				DefaultFunc: schema.EnvDefaultFunc("LOOKER_API_BASE_URL", nil),
-				Description: "Looker API Base URL",
+				Description: "Looker API Base URL, either a host and port or a url such as https://example.looker.com:19999",
+			},
+			"api_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("LOOKER_API_VERSION", apiVersion30),
+				ValidateFunc: validation.StringInSlice([]string{apiVersion30, apiVersion40}, false),
+				Description:  "Version of the Looker API to use, either 3.0 or 4.0",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of times a request failing with a transient error is retried",
+			},
+			"retry_min_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Seconds to wait before the first retry, doubled on every following retry",
+			},
+			"retry_max_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of seconds to wait between retries",
+			},
+			"live_permission_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Validate the permissions of permission sets against the permissions the instance lists, instead of the ones built into the provider",
+			},
+			"strict_permission_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fail the plan when a permission set has a permission missing from the permissions built into the provider, instead of warning on apply",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"looker_user":                    resourceUser(),
-			"looker_user_email":              resourceUserEmail(),
-			"looker_user_roles":              resourceUserRoles(),
-			"looker_user_api_key":            resourceUserAPIKey(),
-			"looker_permission_set":          resourcePermissionSet(),
-			"looker_model_set":               resourceModelSet(),
-			"looker_group":                   resourceGroup(),
-			"looker_role":                    resourceRole(),
-			"looker_role_groups":             resourceRoleGroups(),
-			"looker_main_space":              resourceMainSpace(),
-			"looker_child_space":             resourceChildSpace(),
-			"looker_content_metadata_access": resourceContentMetadataAccess(),
-			"looker_connection":              resourceConnection(),
-			"looker_project":                 resourceProject(),
-			"looker_git_deploy_key":          resourceGitDeployKey(),
-			"looker_project_git_details":     resourceProjectGitDetails(),
-			"looker_user_attribute":          resourceUserAttribute(),
+			"looker_user":                       resourceUser(),
+			"looker_user_email":                 resourceUserEmail(),
+			"looker_user_roles":                 resourceUserRoles(),
+			"looker_user_api_key":               resourceUserAPIKey(),
+			"looker_user_credentials_saml":      resourceUserCredentials(userCredentialsSAML),
+			"looker_user_credentials_oidc":      resourceUserCredentials(userCredentialsOIDC),
+			"looker_user_credentials_ldap":      resourceUserCredentials(userCredentialsLDAP),
+			"looker_user_credentials_google":    resourceUserCredentials(userCredentialsGoogle),
+			"looker_user_credentials_embed":     resourceUserCredentials(userCredentialsEmbed),
+			"looker_permission_set":             resourcePermissionSet(),
+			"looker_model_set":                  resourceModelSet(),
+			"looker_group":                      resourceGroup(),
+			"looker_group_users":                resourceGroupUsers(),
+			"looker_group_user":                 resourceGroupUser(),
+			"looker_group_groups":               resourceGroupGroups(),
+			"looker_role":                       resourceRole(),
+			"looker_role_groups":                resourceRoleGroups(),
+			"looker_main_space":                 resourceMainSpace(),
+			"looker_child_space":                resourceChildSpace(),
+			"looker_folder":                     resourceFolder(),
+			"looker_content_metadata_access":    resourceContentMetadataAccess(),
+			"looker_connection":                 resourceConnection(),
+			"looker_project":                    resourceProject(),
+			"looker_git_deploy_key":             resourceGitDeployKey(),
+			"looker_project_git_deploy_key":     resourceProjectGitDeployKey(),
+			"looker_project_git_details":        resourceProjectGitDetails(),
+			"looker_user_attribute":             resourceUserAttribute(),
+			"looker_user_attribute_group_value": resourceUserAttributeGroupValue(),
+			"looker_user_attribute_user_value":  resourceUserAttributeUserValue(),
+			"looker_saml_config":                resourceAuthConfig(samlConfigType),
+			"looker_oidc_config":                resourceAuthConfig(oidcConfigType),
+			"looker_ldap_config":                resourceAuthConfig(ldapConfigType),
 		},
 
-		ConfigureFunc: providerConfigure,
+		DataSourcesMap: map[string]*schema.Resource{
+			"looker_user":            dataSourceUser(),
+			"looker_dialect_info":    dataSourceDialectInfo(),
+			"looker_connection_test": dataSourceConnectionTest(),
+			"looker_role":            dataSourceRole(),
+			"looker_group":           dataSourceGroup(),
+			"looker_permission_set":  dataSourcePermissionSet(),
+			"looker_model_set":       dataSourceModelSet(),
+		},
+
+		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	transport := httptransport.New(d.Get("base_url").(string), "/api/3.0/", nil)
-	client := apiclient.New(transport, strfmt.Default)
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	apiVersion := d.Get("api_version").(string)
+
+	host, schemes := parseBaseURL(d.Get("base_url").(string))
+
+	transport := httptransport.New(host, "/api/"+apiVersion+"/", schemes)
+	transport.Transport = newRetryTransport(
+		transport.Transport,
+		d.Get("max_retries").(int),
+		time.Duration(d.Get("retry_min_backoff").(int))*time.Second,
+		time.Duration(d.Get("retry_max_backoff").(int))*time.Second,
+	)
 
 	clientID := d.Get("client_id").(string)
 	clientSecret := d.Get("client_secret").(string)
 
-	pd := api_auth.NewLoginParams()
-	pd.ClientID = &clientID
-	pd.ClientSecret = &clientSecret
+	var apiTransport runtime.ClientTransport = transport
+	if apiVersion == apiVersion40 {
+		apiTransport = newAPI40Transport(transport)
+	}
 
-	resp, err := client.APIAuth.Login(pd)
+	authTransport := newAuthTransport(apiTransport, clientID, clientSecret)
+	client := newClient(authTransport, apiVersion)
+	authTransport.afterLogin = client.sessions.afterLogin
+	client.permissions = newPermissionValidator(apiVersion, d.Get("live_permission_validation").(bool), d.Get("strict_permission_validation").(bool), client.Role)
 
+	// log in up front so bad credentials are reported when the provider is configured
+	_, err := authTransport.accessToken()
 	if err != nil {
-		return nil, err
+		return nil, diag.FromErr(err)
 	}
 
-	token := resp.Payload.AccessToken
-	log.Println("[INFO] token " + token)
-
-	authInfoWriter := httptransport.APIKeyAuth("Authorization", "header", "token "+token)
-	transport.DefaultAuthentication = authInfoWriter
-
-	authClient := apiclient.New(transport, strfmt.Default)
+	return client, nil
+}
 
-	return authClient, nil
+// parseBaseURL splits base_url into the host and, when the url includes one, the scheme to use. Without a scheme
+// the API is called over https.
+func parseBaseURL(baseURL string) (string, []string) {
+	if i := strings.Index(baseURL, "://"); i >= 0 {
+		return strings.TrimSuffix(baseURL[i+3:], "/"), []string{baseURL[:i]}
+	}
+	return baseURL, nil
 }
@@ -0,0 +1,130 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// The acceptance tests run Terraform against the provider and a lookertest fake, so they need no Looker instance.
+// As all acceptance tests they only run with TF_ACC set; Terraform is downloaded unless TF_ACC_TERRAFORM_PATH points
+// at a binary.
+
+var testAccProviderFactories = map[string]func() (*schema.Provider, error){
+	"looker": func() (*schema.Provider, error) {
+		return Provider(), nil
+	},
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testAccServer starts a fake Looker API, closed when the test ends
+func testAccServer(t *testing.T) *lookertest.Server {
+	server := lookertest.NewServer()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestClient configures the provider for a fake and returns its client, for tests calling the client directly
+func newTestClient(t *testing.T, server *lookertest.Server) *Client {
+	t.Helper()
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"base_url":      server.BaseURL(),
+		"client_id":     lookertest.ClientID,
+		"client_secret": lookertest.ClientSecret,
+	}))
+	if diags.HasError() {
+		t.Fatalf("Can't configure the provider: %v", diags)
+	}
+
+	return p.Meta().(*Client)
+}
+
+// testAccCheckExists checks the object of a resource in the state is stored by the fake
+func testAccCheckExists(server *lookertest.Server, collection string, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s is not in the state", name)
+		}
+		if _, ok := server.Get(collection, rs.Primary.ID); !ok {
+			return fmt.Errorf("%s %s was not found in %s", name, rs.Primary.ID, collection)
+		}
+		return nil
+	}
+}
+
+// testAccCheckRemoteAttr checks a field of the object of a resource in the state, as stored by the fake
+func testAccCheckRemoteAttr(server *lookertest.Server, collection string, name string, key string, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s is not in the state", name)
+		}
+		obj, ok := server.Get(collection, rs.Primary.ID)
+		if !ok {
+			return fmt.Errorf("%s %s was not found in %s", name, rs.Primary.ID, collection)
+		}
+		if got := fmt.Sprint(obj[key]); got != value {
+			return fmt.Errorf("%s %s has %s %q, expected %q", name, rs.Primary.ID, key, got, value)
+		}
+		return nil
+	}
+}
+
+// testAccCheckDestroy checks the objects of every resource of a type in the state were deleted from the fake
+func testAccCheckDestroy(server *lookertest.Server, collection string, resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			if _, ok := server.Get(collection, rs.Primary.ID); ok {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+// testAccCheckResourceAttrValue stores an attribute of a resource in the state, e.g. to check it changes in a later step
+func testAccCheckResourceAttrValue(name string, key string, value *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s is not in the state", name)
+		}
+		v, ok := rs.Primary.Attributes[key]
+		if !ok {
+			return fmt.Errorf("%s has no %s", name, key)
+		}
+		*value = v
+		return nil
+	}
+}
+
+// testAccCheckResourceAttrChanged checks an attribute stored by testAccCheckResourceAttrValue changed, and stores the
+// new value
+func testAccCheckResourceAttrChanged(name string, key string, value *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		previous := *value
+		if err := testAccCheckResourceAttrValue(name, key, value)(s); err != nil {
+			return err
+		}
+		if *value == previous {
+			return fmt.Errorf("%s %s didn't change from %q", name, key, previous)
+		}
+		return nil
+	}
+}
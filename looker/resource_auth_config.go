@@ -0,0 +1,618 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// authConfigType describes one of the SAML, OIDC and LDAP configurations, which are singletons: there is one of each
+// per instance, always present, and disabled until configured.
+type authConfigType struct {
+	// the name of the configuration, e.g. "saml", which is also its resource's ID as "saml_config"
+	name string
+	// the scalar fields of the configuration besides the ones all of them have, named after the API
+	fields map[string]*schema.Schema
+	// the fields the API accepts but never returns, kept hashed in the state
+	secrets []string
+	get     func(client *Client) (authConfig, error)
+	update  func(client *Client, body authConfig) (authConfig, error)
+	// test, if set, checks a configuration before it's applied, when test_connection_on_plan is set
+	test func(client *Client, body authConfig) error
+	// the fields the test depends on, which run it again when they change
+	testedFields []string
+}
+
+// authConfigFields are the scalar fields of all the configurations
+var authConfigFields = map[string]*schema.Schema{
+	"enabled": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Computed: true,
+	},
+	// lets users log in with their email and password besides the identity provider
+	"alternate_email_login_allowed": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Computed: true,
+	},
+	// rejects users that get no role from their groups
+	"auth_requires_role": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Computed: true,
+	},
+	// gives users the roles of their groups in the groups blocks at each login
+	"set_roles_from_groups": &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Computed: true,
+	},
+	"user_attribute_map_email": &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	},
+	"user_attribute_map_first_name": &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	},
+	"user_attribute_map_last_name": &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	},
+	// the groups and roles of the users the identity provider creates
+	"default_new_user_group_ids": &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
+	"default_new_user_role_ids": &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
+}
+
+func optionalString() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+}
+
+func optionalBool() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Computed: true,
+	}
+}
+
+func optionalSecret() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Sensitive:        true,
+		DiffSuppressFunc: suppressSecretDiff,
+	}
+}
+
+var samlConfigType = &authConfigType{
+	name: "saml",
+	fields: map[string]*schema.Schema{
+		"idp_url":      optionalString(),
+		"idp_issuer":   optionalString(),
+		"idp_audience": optionalString(),
+		// the certificate of the identity provider, in PEM format
+		"idp_cert": optionalString(),
+		// the seconds of clock drift allowed between Looker and the identity provider
+		"allowed_clock_drift": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+		// sends users to the identity provider instead of Looker's login page
+		"bypass_login_page":        optionalBool(),
+		"groups_attribute":         optionalString(),
+		"groups_finder_type":       optionalString(),
+		"groups_member_value":      optionalString(),
+		"new_user_migration_types": optionalString(),
+	},
+	get: func(client *Client) (authConfig, error) {
+		return client.Auth.samlConfig()
+	},
+	update: func(client *Client, body authConfig) (authConfig, error) {
+		return client.Auth.updateSamlConfig(body)
+	},
+}
+
+var oidcConfigType = &authConfigType{
+	name: "oidc",
+	fields: map[string]*schema.Schema{
+		"issuer":                 optionalString(),
+		"audience":               optionalString(),
+		"authorization_endpoint": optionalString(),
+		"token_endpoint":         optionalString(),
+		"userinfo_endpoint":      optionalString(),
+		// the client ID of Looker at the identity provider
+		"identifier": optionalString(),
+		// the client secret of Looker at the identity provider
+		"secret": optionalSecret(),
+		"scopes": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"groups_attribute":         optionalString(),
+		"new_user_migration_types": optionalString(),
+	},
+	secrets: []string{"secret"},
+	get: func(client *Client) (authConfig, error) {
+		return client.Auth.oidcConfig()
+	},
+	update: func(client *Client, body authConfig) (authConfig, error) {
+		return client.Auth.updateOidcConfig(body)
+	},
+}
+
+var ldapConfigType = &authConfigType{
+	name: "ldap",
+	fields: map[string]*schema.Schema{
+		"connection_host":          optionalString(),
+		"connection_port":          optionalString(),
+		"connection_tls":           optionalBool(),
+		"connection_tls_no_verify": optionalBool(),
+		// the user Looker binds as to search the directory
+		"auth_username":              optionalString(),
+		"auth_password":              optionalSecret(),
+		"force_no_page":              optionalBool(),
+		"merge_new_users_by_email":   optionalBool(),
+		"user_bind_base_dn":          optionalString(),
+		"user_custom_filter":         optionalString(),
+		"user_id_attribute_names":    optionalString(),
+		"user_objectclass":           optionalString(),
+		"user_attribute_map_ldap_id": optionalString(),
+		"groups_base_dn":             optionalString(),
+		"groups_finder_type":         optionalString(),
+		"groups_member_attribute":    optionalString(),
+		"groups_objectclasses":       optionalString(),
+		"groups_user_attribute":      optionalString(),
+		// the user the admin UI tests authentication with
+		"test_ldap_user":     optionalString(),
+		"test_ldap_password": optionalSecret(),
+	},
+	secrets: []string{"auth_password", "test_ldap_password"},
+	get: func(client *Client) (authConfig, error) {
+		return client.Auth.ldapConfig()
+	},
+	update: func(client *Client, body authConfig) (authConfig, error) {
+		return client.Auth.updateLdapConfig(body)
+	},
+	test:         testLdapConnection,
+	testedFields: []string{"connection_host", "connection_port", "connection_tls", "connection_tls_no_verify", "auth_username", "auth_password"},
+}
+
+// resourceAuthConfig manages a SAML, OIDC or LDAP configuration. The scalar fields left out of the configuration are
+// only read, while the groups and user_attributes mappings are authoritative.
+func resourceAuthConfig(configType *authConfigType) *schema.Resource {
+	s := map[string]*schema.Schema{
+		// maps the groups of the identity provider to Looker roles
+		"groups": &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": &schema.Schema{
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"role_ids": &schema.Schema{
+						Type:     schema.TypeSet,
+						Required: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		// maps the attributes of the identity provider to Looker user attributes
+		"user_attributes": &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": &schema.Schema{
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"user_attribute_ids": &schema.Schema{
+						Type:     schema.TypeSet,
+						Required: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					// rejects users without the attribute
+					"required": &schema.Schema{
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+				},
+			},
+		},
+		// disables the authentication method on destroy, which otherwise leaves the configuration as is
+		"disable_on_destroy": &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+	// copies, as the resources can't share their schemas
+	for key, field := range authConfigFields {
+		f := *field
+		s[key] = &f
+	}
+	for key, field := range configType.fields {
+		f := *field
+		s[key] = &f
+	}
+
+	r := &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceAuthConfigCreate(ctx, d, m, configType)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceAuthConfigRead(ctx, d, m, configType)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceAuthConfigUpdate(ctx, d, m, configType)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceAuthConfigDelete(ctx, d, m, configType)
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: s,
+	}
+
+	if configType.test != nil {
+		// tests the configuration when planning a change to the fields it depends on, failing the plan if the test
+		// fails
+		s["test_connection_on_plan"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		}
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			return resourceAuthConfigTest(ctx, d, m, configType)
+		}
+	}
+
+	return r
+}
+
+// authConfigData is what getAuthConfigBody needs of a *schema.ResourceData or *schema.ResourceDiff
+type authConfigData interface {
+	Id() string
+	Get(key string) interface{}
+	HasChange(key string) bool
+	GetRawConfig() cty.Value
+}
+
+// getAuthConfigBody returns the configured fields of d. When onlyChanged is set, the fields of an existing
+// configuration are only sent when they changed.
+func getAuthConfigBody(d authConfigData, configType *authConfigType, onlyChanged bool) (authConfig, error) {
+	send := func(key string) bool {
+		return !onlyChanged || d.Id() == "" || d.HasChange(key)
+	}
+
+	config := d.GetRawConfig()
+	configured := func(key string) bool {
+		return !config.IsNull() && !config.GetAttr(key).IsNull()
+	}
+
+	body := authConfig{}
+
+	fields := map[string]*schema.Schema{}
+	for key, field := range authConfigFields {
+		fields[key] = field
+	}
+	for key, field := range configType.fields {
+		fields[key] = field
+	}
+
+	for key, field := range fields {
+		if !configured(key) || !send(key) {
+			continue
+		}
+
+		switch {
+		case strings.HasSuffix(key, "_ids"):
+			ids, err := getIDsFromStrings(d.Get(key).(*schema.Set).List())
+			if err != nil {
+				return nil, err
+			}
+			body[key] = ids
+		case field.Type == schema.TypeList:
+			values := []string{}
+			for _, value := range d.Get(key).([]interface{}) {
+				values = append(values, value.(string))
+			}
+			body[key] = values
+		default:
+			body[key] = d.Get(key)
+		}
+	}
+
+	if send("groups") {
+		groups := []map[string]interface{}{}
+		for _, group := range d.Get("groups").(*schema.Set).List() {
+			group := group.(map[string]interface{})
+			roleIDs, err := getIDsFromStrings(group["role_ids"].(*schema.Set).List())
+			if err != nil {
+				return nil, err
+			}
+			groups = append(groups, map[string]interface{}{
+				"name":     group["name"],
+				"role_ids": roleIDs,
+			})
+		}
+		body["groups_with_role_ids"] = groups
+	}
+
+	if send("user_attributes") {
+		attributes := []map[string]interface{}{}
+		for _, attribute := range d.Get("user_attributes").(*schema.Set).List() {
+			attribute := attribute.(map[string]interface{})
+			userAttributeIDs, err := getIDsFromStrings(attribute["user_attribute_ids"].(*schema.Set).List())
+			if err != nil {
+				return nil, err
+			}
+			attributes = append(attributes, map[string]interface{}{
+				"name":               attribute["name"],
+				"required":           attribute["required"],
+				"user_attribute_ids": userAttributeIDs,
+			})
+		}
+		body["user_attributes_with_ids"] = attributes
+	}
+
+	return body, nil
+}
+
+// getIDsFromStrings parses a list of IDs in the state
+func getIDsFromStrings(values []interface{}) ([]int64, error) {
+	ids := []int64{}
+	for _, value := range values {
+		id, err := getIDFromString(value.(string))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// getStringsFromJSON returns the values of a JSON list as strings, e.g. IDs, which API 3.0 returns as numbers
+func getStringsFromJSON(value interface{}) []string {
+	values := []string{}
+	list, _ := value.([]interface{})
+	for _, item := range list {
+		if item != nil {
+			values = append(values, getStringFromJSON(item))
+		}
+	}
+	return values
+}
+
+// getStringFromJSON formats a JSON scalar. Numbers decoded as float64 are formatted in full, as fmt prints the ones
+// from a million on with an exponent, e.g. 1e+06.
+func getStringFromJSON(value interface{}) string {
+	if f, ok := value.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// authConfigValue converts a JSON value of a configuration to the type of its field
+func authConfigValue(field *schema.Schema, value interface{}) interface{} {
+	switch field.Type {
+	case schema.TypeBool:
+		b, _ := value.(bool)
+		return b
+	case schema.TypeInt:
+		n, _ := getIDFromString(getStringFromJSON(value))
+		return int(n)
+	case schema.TypeList, schema.TypeSet:
+		return getStringsFromJSON(value)
+	}
+
+	if value == nil {
+		return ""
+	}
+	return getStringFromJSON(value)
+}
+
+func resourceAuthConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}, configType *authConfigType) diag.Diagnostics {
+	client := m.(*Client)
+
+	body, err := getAuthConfigBody(d, configType, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = configType.update(client, body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(configType.name + "_config")
+	setSecretHashes(d, configType.secrets...)
+
+	return resourceAuthConfigRead(ctx, d, m, configType)
+}
+
+func resourceAuthConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}, configType *authConfigType) diag.Diagnostics {
+	client := m.(*Client)
+
+	config, err := configType.get(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	secrets := map[string]bool{}
+	for _, key := range configType.secrets {
+		secrets[key] = true
+	}
+
+	for key, field := range authConfigFields {
+		d.Set(key, authConfigValue(field, config[key]))
+	}
+	for key, field := range configType.fields {
+		if !secrets[key] {
+			d.Set(key, authConfigValue(field, config[key]))
+		}
+	}
+
+	groups := []map[string]interface{}{}
+	list, _ := config["groups_with_role_ids"].([]interface{})
+	for _, item := range list {
+		group, _ := item.(map[string]interface{})
+		groups = append(groups, map[string]interface{}{
+			"name":     authConfigValue(&schema.Schema{Type: schema.TypeString}, group["name"]),
+			"role_ids": getStringsFromJSON(group["role_ids"]),
+		})
+	}
+	d.Set("groups", groups)
+
+	attributes := []map[string]interface{}{}
+	list, _ = config["user_attributes_with_ids"].([]interface{})
+	for _, item := range list {
+		attribute, _ := item.(map[string]interface{})
+		attributes = append(attributes, map[string]interface{}{
+			"name":               authConfigValue(&schema.Schema{Type: schema.TypeString}, attribute["name"]),
+			"required":           authConfigValue(&schema.Schema{Type: schema.TypeBool}, attribute["required"]),
+			"user_attribute_ids": getStringsFromJSON(attribute["user_attribute_ids"]),
+		})
+	}
+	d.Set("user_attributes", attributes)
+
+	return nil
+}
+
+func resourceAuthConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}, configType *authConfigType) diag.Diagnostics {
+	client := m.(*Client)
+
+	body, err := getAuthConfigBody(d, configType, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = configType.update(client, body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, key := range configType.secrets {
+		if d.HasChange(key) {
+			setSecretHashes(d, key)
+		}
+	}
+
+	return resourceAuthConfigRead(ctx, d, m, configType)
+}
+
+// resourceAuthConfigDelete disables the authentication method if disable_on_destroy is set. The configuration itself
+// can't be deleted, so it's otherwise left as is.
+func resourceAuthConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}, configType *authConfigType) diag.Diagnostics {
+	client := m.(*Client)
+
+	if d.Get("disable_on_destroy").(bool) {
+		_, err := configType.update(client, authConfig{"enabled": false})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("The %s configuration was left as is", strings.ToUpper(configType.name)),
+		Detail:   "Looker's authentication configurations can't be deleted, so it was only removed from the Terraform state. Set disable_on_destroy to disable the authentication method on destroy.",
+	}}
+}
+
+// resourceAuthConfigTest tests the planned configuration when test_connection_on_plan is set and the fields the test
+// depends on change
+func resourceAuthConfigTest(ctx context.Context, d *schema.ResourceDiff, m interface{}, configType *authConfigType) error {
+	client, ok := m.(*Client)
+	if !ok || client == nil || !d.Get("test_connection_on_plan").(bool) {
+		return nil
+	}
+
+	config := d.GetRawConfig()
+	secrets := map[string]bool{}
+	for _, key := range configType.secrets {
+		secrets[key] = true
+	}
+
+	changed := d.Id() == ""
+	body := authConfig{}
+	for _, key := range configType.testedFields {
+		if config.IsNull() || config.GetAttr(key).IsNull() {
+			continue
+		}
+		if !config.GetAttr(key).IsKnown() {
+			log.Printf("[WARN] Can't test the %s configuration at plan time, %s is only known on apply", configType.name, key)
+			return nil
+		}
+		changed = changed || d.HasChange(key)
+
+		value := d.Get(key)
+		// unchanged secrets are only known to Looker, which tests with the saved ones
+		if secrets[key] && strings.HasPrefix(value.(string), secretHashPrefix) {
+			continue
+		}
+		body[key] = value
+	}
+	if !changed {
+		return nil
+	}
+
+	return configType.test(client, body)
+}
+
+// testLdapConnection fails with the issues of the test when Looker can't connect to the LDAP server of body
+func testLdapConnection(client *Client, body authConfig) error {
+	result, err := client.Auth.testLdapConfigConnection(body)
+	if err != nil {
+		return fmt.Errorf("Can't test the LDAP connection: %s", err)
+	}
+
+	if result.Status == "success" {
+		return nil
+	}
+
+	lines := []string{result.Message}
+	for _, issue := range result.Issues {
+		lines = append(lines, fmt.Sprintf("%s: %s", issue.Severity, issue.Message))
+	}
+
+	return fmt.Errorf("The LDAP connection test failed with status %s: %s", result.Status, strings.Join(lines, "\n"))
+}
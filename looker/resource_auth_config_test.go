@@ -0,0 +1,213 @@
+package looker
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAuthConfigValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		field *schema.Schema
+		value interface{}
+		want  interface{}
+	}{
+		{"float id", &schema.Schema{Type: schema.TypeString}, float64(1000000), "1000000"},
+		{"large float id", &schema.Schema{Type: schema.TypeString}, float64(123456789012), "123456789012"},
+		{"number id", &schema.Schema{Type: schema.TypeString}, json.Number("1000000"), "1000000"},
+		{"string", &schema.Schema{Type: schema.TypeString}, "https://idp.example.com", "https://idp.example.com"},
+		{"null string", &schema.Schema{Type: schema.TypeString}, nil, ""},
+		{"float int", &schema.Schema{Type: schema.TypeInt}, float64(3600000), 3600000},
+		{"number int", &schema.Schema{Type: schema.TypeInt}, json.Number("3600000"), 3600000},
+		{"null int", &schema.Schema{Type: schema.TypeInt}, nil, 0},
+		{"bool", &schema.Schema{Type: schema.TypeBool}, true, true},
+		{"null bool", &schema.Schema{Type: schema.TypeBool}, nil, false},
+		{
+			"float ids",
+			&schema.Schema{Type: schema.TypeSet, Elem: &schema.Schema{Type: schema.TypeString}},
+			[]interface{}{float64(7), float64(1000000), nil, json.Number("2000000")},
+			[]string{"7", "1000000", "2000000"},
+		},
+		{"null ids", &schema.Schema{Type: schema.TypeSet, Elem: &schema.Schema{Type: schema.TypeString}}, nil, []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := authConfigValue(test.field, test.value); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("authConfigValue(%#v) = %#v, want %#v", test.value, got, test.want)
+			}
+		})
+	}
+}
+
+func TestAccLookerSamlConfig(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAuthConfigDisabled(server, "saml"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerSamlConfigConfig(server, "https://idp.example.com/sso"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_saml_config.test", "id", "saml_config"),
+					resource.TestCheckResourceAttr("looker_saml_config.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("looker_saml_config.test", "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("looker_saml_config.test", "groups.*.role_ids.*", "data.looker_role.admin", "id"),
+					testAccCheckAuthConfig(server, "saml", "idp_url", "https://idp.example.com/sso"),
+				),
+			},
+			{
+				Config: testAccLookerSamlConfigConfig(server, "https://idp.example.com/sso/v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_saml_config.test", "idp_url", "https://idp.example.com/sso/v2"),
+					testAccCheckAuthConfig(server, "saml", "idp_url", "https://idp.example.com/sso/v2"),
+				),
+			},
+			{
+				ResourceName:            "looker_saml_config.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"disable_on_destroy"},
+			},
+		},
+	})
+}
+
+func testAccLookerSamlConfigConfig(server *lookertest.Server, idpURL string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+data "looker_role" "admin" {
+  name = "Admin"
+}
+
+resource "looker_saml_config" "test" {
+  enabled             = true
+  idp_url             = %q
+  idp_issuer          = "http://idp.example.com/issuer"
+  allowed_clock_drift = 30
+  disable_on_destroy  = true
+
+  groups {
+    name     = "looker-admins"
+    role_ids = [data.looker_role.admin.id]
+  }
+}
+`, idpURL)
+}
+
+func TestAccLookerOidcConfig(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerOidcConfigConfig(server, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("looker_oidc_config.test", "secret", regexp.MustCompile("^"+secretHashPrefix)),
+					resource.TestCheckResourceAttr("looker_oidc_config.test", "scopes.#", "2"),
+					testAccCheckAuthConfig(server, "oidc", "secret", "first"),
+				),
+			},
+			{
+				Config: testAccLookerOidcConfigConfig(server, "second"),
+				Check:  testAccCheckAuthConfig(server, "oidc", "secret", "second"),
+			},
+			{
+				ResourceName:            "looker_oidc_config.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret", "disable_on_destroy"},
+			},
+		},
+	})
+
+	// destroying without disable_on_destroy leaves the configuration as is
+	if err := testAccCheckAuthConfig(server, "oidc", "enabled", "true")(nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAccLookerOidcConfigConfig(server *lookertest.Server, secret string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_oidc_config" "test" {
+  enabled    = true
+  issuer     = "https://idp.example.com"
+  identifier = "looker"
+  secret     = %q
+  scopes     = ["openid", "email"]
+}
+`, secret)
+}
+
+func TestAccLookerLdapConfig(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccLookerLdapConfigConfig(server, "ldap.example.com", lookertest.WrongPassword),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid credentials"),
+			},
+			{
+				Config: testAccLookerLdapConfigConfig(server, "ldap.example.com", "secret"),
+				Check:  testAccCheckAuthConfig(server, "ldap", "connection_host", "ldap.example.com"),
+			},
+			{
+				// the saved password is tested when it doesn't change
+				Config:      testAccLookerLdapConfigConfig(server, "ldap.invalid", "secret"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Cannot connect to ldap.invalid"),
+			},
+			{
+				ResourceName:            "looker_ldap_config.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"auth_password", "test_ldap_password", "test_connection_on_plan", "disable_on_destroy"},
+			},
+		},
+	})
+}
+
+func testAccLookerLdapConfigConfig(server *lookertest.Server, host string, password string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_ldap_config" "test" {
+  enabled                 = true
+  connection_host         = %q
+  connection_port         = "636"
+  connection_tls          = true
+  auth_username           = "cn=looker,dc=example,dc=com"
+  auth_password           = %q
+  test_connection_on_plan = true
+}
+`, host, password)
+}
+
+// testAccCheckAuthConfig checks a field of a configuration as stored by the fake
+func testAccCheckAuthConfig(server *lookertest.Server, name string, key string, value string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		config, ok := server.Get("auth_configs", name)
+		if !ok {
+			return fmt.Errorf("the %s configuration was never saved", name)
+		}
+		if got := fmt.Sprint(config[key]); got != value {
+			return fmt.Errorf("the %s configuration has %s %q, expected %q", name, key, got, value)
+		}
+		return nil
+	}
+}
+
+// testAccCheckAuthConfigDisabled checks a configuration destroyed with disable_on_destroy was disabled
+func testAccCheckAuthConfigDisabled(server *lookertest.Server, name string) resource.TestCheckFunc {
+	return testAccCheckAuthConfig(server, name, "enabled", "false")
+}
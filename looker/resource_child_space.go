@@ -1,25 +1,26 @@
 package looker
 
 import (
+	"context"
 	"log"
-	"strings"
 
 	"github.com/billtrust/looker-go-sdk/client/space"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
 	"github.com/billtrust/looker-go-sdk/models"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceChildSpace() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceChildSpaceCreate,
-		Read:   resourceChildSpaceRead,
-		Update: resourceChildSpaceUpdate,
-		Delete: resourceChildSpaceDelete,
-		Exists: resourceChildSpaceExists,
+		DeprecationMessage: "Use looker_folder instead, which handles both root parented and nested folders. Import the folder into a looker_folder resource and remove this one from the state.",
+
+		CreateContext: resourceChildSpaceCreate,
+		ReadContext:   resourceChildSpaceRead,
+		UpdateContext: resourceChildSpaceUpdate,
+		DeleteContext: resourceChildSpaceDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceChildSpaceImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -40,7 +41,7 @@ func resourceChildSpace() *schema.Resource {
 }
 
 func getChildSpaceByID(d *schema.ResourceData, m interface{}, id int64) (*models.Space, error) {
-	client := m.(*apiclient.LookerAPI30Reference)
+	client := m.(*Client)
 
 	params := space.NewSpaceParams()
 	params.SpaceID = id
@@ -54,12 +55,12 @@ func getChildSpaceByID(d *schema.ResourceData, m interface{}, id int64) (*models
 	return result.Payload, nil
 }
 
-func resourceChildSpaceCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceChildSpaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	parentID, err := getIDFromString(d.Get("parent_id").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := space.NewCreateSpaceParams()
@@ -69,27 +70,27 @@ func resourceChildSpaceCreate(d *schema.ResourceData, m interface{}) error {
 
 	result, err := client.Space.CreateSpace(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(getStringFromID(result.Payload.ID))
 
-	return resourceChildSpaceRead(d, m)
+	return resourceChildSpaceRead(ctx, d, m)
 }
 
-func resourceChildSpaceRead(d *schema.ResourceData, m interface{}) error {
+func resourceChildSpaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	space, err := getChildSpaceByID(d, m, ID)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", space.Name)
@@ -99,17 +100,17 @@ func resourceChildSpaceRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceChildSpaceUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceChildSpaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	parentID, err := getIDFromString(d.Get("parent_id").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := space.NewUpdateSpaceParams()
@@ -120,18 +121,18 @@ func resourceChildSpaceUpdate(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Space.UpdateSpace(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceChildSpaceRead(d, m)
+	return resourceChildSpaceRead(ctx, d, m)
 }
 
-func resourceChildSpaceDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceChildSpaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := space.NewDeleteSpaceParams()
@@ -139,35 +140,8 @@ func resourceChildSpaceDelete(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Space.DeleteSpace(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
-
-func resourceChildSpaceExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	ID, err := getIDFromString(d.Id())
-	if err != nil {
-		return false, err
-	}
-
-	_, err = getChildSpaceByID(d, m, ID)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
-		}
-
-		return false, err
-	}
-
-	return true, nil
-}
-
-func resourceChildSpaceImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceChildSpaceRead(d, m); err != nil {
-		return nil, err
-	}
-	return []*schema.ResourceData{d}, nil
-}
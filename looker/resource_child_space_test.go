@@ -0,0 +1,55 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerChildSpace(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(server, "spaces", "looker_child_space"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerChildSpaceConfig(server, "Weekly"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(server, "spaces", "looker_child_space.test"),
+					resource.TestCheckResourceAttrPair("looker_child_space.test", "parent_id", "looker_main_space.parent", "id"),
+					resource.TestCheckResourceAttrSet("looker_child_space.test", "content_metadata_id"),
+				),
+			},
+			{
+				Config: testAccLookerChildSpaceConfig(server, "Monthly"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_child_space.test", "name", "Monthly"),
+					testAccCheckRemoteAttr(server, "spaces", "looker_child_space.test", "name", "Monthly"),
+				),
+			},
+			{
+				ResourceName:      "looker_child_space.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccLookerChildSpaceConfig(server *lookertest.Server, name string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_main_space" "parent" {
+  name                      = "Reports"
+  parent_space_name         = "Shared"
+  content_metadata_inherits = true
+}
+
+resource "looker_child_space" "test" {
+  name      = %q
+  parent_id = looker_main_space.parent.id
+}
+`, name)
+}
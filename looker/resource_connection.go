@@ -1,26 +1,31 @@
 package looker
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"sort"
 	"strings"
 
 	"github.com/billtrust/looker-go-sdk/client/connection"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
 	"github.com/billtrust/looker-go-sdk/models"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/go-openapi/swag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceConnection() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceConnectionCreate,
-		Read:   resourceConnectionRead,
-		Update: resourceConnectionUpdate,
-		Delete: resourceConnectionDelete,
-		Exists: resourceConnectionExists,
+		CreateContext: resourceConnectionCreate,
+		ReadContext:   resourceConnectionRead,
+		UpdateContext: resourceConnectionUpdate,
+		DeleteContext: resourceConnectionDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceConnectionImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceConnectionValidateDialect,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -47,12 +52,13 @@ func resourceConnection() *schema.Resource {
 			},
 			"host": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 			},
+			// the dialect's default port when not set
 			"port": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				Default:  "443",
+				Computed: true,
 			},
 			"database": &schema.Schema{
 				Type:     schema.TypeString,
@@ -60,24 +66,30 @@ func resourceConnection() *schema.Resource {
 			},
 			"username": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 			},
+			// stored as a salted hash, see secrets.go
 			"password": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-					if d.Id() == "" {
-						return false
-					}
-					// TODO: not sure how to handle this scenario.  The terraform sets the password for the connection on create, but this value is not returned on get
-					// TODO: to handle this, DiffSupressFunc always say this field is not changed to handle this.  And if the user changes the field, ForceNew should create a new one
-					return true
-				},
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressSecretDiff,
+			},
+			// the contents of a service account key file, base64 encoded, e.g. for BigQuery
+			"certificate": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressSecretDiff,
+			},
+			"file_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{".json", ".p12"}, false),
 			},
 			"schema": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
 			},
 			"jdbc_additional_params": &schema.Schema{
 				Type:     schema.TypeString,
@@ -87,7 +99,12 @@ func resourceConnection() *schema.Resource {
 			"ssl": &schema.Schema{
 				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  true,
+				Computed: true,
+			},
+			"verify_ssl": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
 			},
 			"db_timezone": &schema.Schema{
 				Type:     schema.TypeString,
@@ -97,134 +114,295 @@ func resourceConnection() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"tmp_db_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"max_connections": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"pdt_concurrency": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"pool_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"maintenance_cron": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"sql_runner_precache_tables": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"after_connect_statements": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user_db_credentials": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"user_attribute_fields": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"max_billing_gigabytes": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"uses_oauth": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"oauth_application_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// runs the connection's tests after every create and update, failing the apply if one of them fails
+			"test_on_apply": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// all the tests of the dialect when empty
+						"tests": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
-func resourceConnectionCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
-
-	params := connection.NewCreateConnectionParams()
-	params.Body = &models.DBConnection{}
-	params.Body.Name = d.Get("name").(string)
-	params.Body.DialectName = d.Get("dialect_name").(string)
-	params.Body.Host = d.Get("host").(string)
-	params.Body.Port = d.Get("port").(string)
-	params.Body.Database = d.Get("database").(string)
-	params.Body.Username = d.Get("username").(string)
-	params.Body.Password = d.Get("password").(string)
-	params.Body.Schema = d.Get("schema").(string)
-	params.Body.JdbcAdditionalParams = d.Get("jdbc_additional_params").(string)
-	params.Body.Ssl = d.Get("ssl").(bool)
-	params.Body.DbTimezone = d.Get("db_timezone").(string)
-	params.Body.QueryTimezone = d.Get("query_timezone").(string)
-
-	result, err := client.Connection.CreateConnection(params)
-	if err != nil {
-		return err
+// connectionSecrets are the fields kept in the state as hashes
+var connectionSecrets = []string{"password", "certificate"}
+
+// getConnectionBody returns the connection configured in d. Secrets are only sent when they changed, as the state
+// only holds their hashes.
+func getConnectionBody(d *schema.ResourceData) *dbConnection {
+	// flags left out of the configuration are left to Looker: its default on create, their current value on update
+	config := d.GetRawConfig()
+	configuredBool := func(key string) *bool {
+		if config.IsNull() || config.GetAttr(key).IsNull() {
+			return nil
+		}
+		return swag.Bool(d.Get(key).(bool))
 	}
 
-	d.SetId(result.Payload.Name)
+	body := &dbConnection{DBConnection: &models.DBConnection{}}
+	body.Name = d.Get("name").(string)
+	body.DialectName = d.Get("dialect_name").(string)
+	body.Host = d.Get("host").(string)
+	body.Port = d.Get("port").(string)
+	body.Database = d.Get("database").(string)
+	body.Username = d.Get("username").(string)
+	if d.HasChange("password") {
+		body.Password = d.Get("password").(string)
+	}
+	if d.HasChange("certificate") {
+		body.Certificate = d.Get("certificate").(string)
+	}
+	body.FileType = d.Get("file_type").(string)
+	body.Schema = d.Get("schema").(string)
+	body.JdbcAdditionalParams = d.Get("jdbc_additional_params").(string)
+	body.Ssl = configuredBool("ssl")
+	body.VerifySsl = configuredBool("verify_ssl")
+	body.DbTimezone = d.Get("db_timezone").(string)
+	body.QueryTimezone = d.Get("query_timezone").(string)
+	body.TmpDbName = d.Get("tmp_db_name").(string)
+	body.MaxConnections = int64(d.Get("max_connections").(int))
+	body.PdtConcurrency = int64(d.Get("pdt_concurrency").(int))
+	body.PoolTimeout = int64(d.Get("pool_timeout").(int))
+	body.MaintenanceCron = d.Get("maintenance_cron").(string)
+	body.SQLRunnerPrecacheTables = configuredBool("sql_runner_precache_tables")
+	body.AfterConnectStatements = d.Get("after_connect_statements").(string)
+	body.UserDbCredentials = configuredBool("user_db_credentials")
+	body.UserAttributeFields = getStringSet(d, "user_attribute_fields")
+	body.MaxBillingGigabytes = d.Get("max_billing_gigabytes").(string)
+	body.UsesOauth = configuredBool("uses_oauth")
+	body.OauthApplicationID = d.Get("oauth_application_id").(string)
 
-	return resourceConnectionRead(d, m)
+	return body
 }
 
-func resourceConnectionRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
-	params := connection.NewConnectionParams()
-	params.ConnectionName = d.Id()
+	body := getConnectionBody(d)
+	if body.Port == "" {
+		if info, err := client.dialects.get(body.DialectName); err == nil {
+			body.Port = info.DefaultPort
+		}
+	}
 
-	result, err := client.Connection.Connection(params)
+	result, err := client.Connection.createConnection(body)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(result.Name)
+	setSecretHashes(d, connectionSecrets...)
+
+	if diags := checkConnection(client, d); diags.HasError() {
+		return diags
+	}
+
+	return resourceConnectionRead(ctx, d, m)
+}
+
+func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	result, err := client.Connection.getConnection(d.Id())
+	if err != nil {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
-	d.Set("name", result.Payload.Name)
-	d.Set("dialect_name", result.Payload.DialectName)
-	d.Set("host", result.Payload.Host)
-	d.Set("port", result.Payload.Port)
-	d.Set("database", result.Payload.Database)
-	d.Set("username", result.Payload.Username)
-	d.Set("password", result.Payload.Password)
-	d.Set("schema", result.Payload.Schema)
-	d.Set("jdbc_additional_params", result.Payload.JdbcAdditionalParams)
-	d.Set("ssl", result.Payload.Ssl)
-	d.Set("db_timezone", result.Payload.DbTimezone)
-	d.Set("query_timezone", result.Payload.QueryTimezone)
+	// password and certificate are never returned
+	d.Set("name", result.Name)
+	d.Set("dialect_name", result.DialectName)
+	d.Set("host", result.Host)
+	d.Set("port", result.Port)
+	d.Set("database", result.Database)
+	d.Set("username", result.Username)
+	d.Set("file_type", result.FileType)
+	d.Set("schema", result.Schema)
+	d.Set("jdbc_additional_params", result.JdbcAdditionalParams)
+	d.Set("ssl", swag.BoolValue(result.Ssl))
+	d.Set("verify_ssl", swag.BoolValue(result.VerifySsl))
+	d.Set("db_timezone", result.DbTimezone)
+	d.Set("query_timezone", result.QueryTimezone)
+	d.Set("tmp_db_name", result.TmpDbName)
+	d.Set("max_connections", result.MaxConnections)
+	d.Set("pdt_concurrency", result.PdtConcurrency)
+	d.Set("pool_timeout", result.PoolTimeout)
+	d.Set("maintenance_cron", result.MaintenanceCron)
+	d.Set("sql_runner_precache_tables", swag.BoolValue(result.SQLRunnerPrecacheTables))
+	d.Set("after_connect_statements", result.AfterConnectStatements)
+	d.Set("user_db_credentials", swag.BoolValue(result.UserDbCredentials))
+	d.Set("user_attribute_fields", result.UserAttributeFields)
+	d.Set("max_billing_gigabytes", result.MaxBillingGigabytes)
+	d.Set("uses_oauth", swag.BoolValue(result.UsesOauth))
+	d.Set("oauth_application_id", result.OauthApplicationID)
 
 	return nil
 }
 
-func resourceConnectionUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
-
-	params := connection.NewUpdateConnectionParams()
-	params.ConnectionName = d.Get("name").(string)
-	params.Body = &models.DBConnection{}
-	params.Body.Name = d.Get("name").(string)
-	params.Body.DialectName = d.Get("dialect_name").(string)
-	params.Body.Host = d.Get("host").(string)
-	params.Body.Port = d.Get("port").(string)
-	params.Body.Database = d.Get("database").(string)
-	params.Body.Username = d.Get("username").(string)
-	params.Body.Password = d.Get("password").(string)
-	params.Body.Schema = d.Get("schema").(string)
-	params.Body.JdbcAdditionalParams = d.Get("jdbc_additional_params").(string)
-	params.Body.Ssl = d.Get("ssl").(bool)
-	params.Body.DbTimezone = d.Get("db_timezone").(string)
-	params.Body.QueryTimezone = d.Get("query_timezone").(string)
-
-	_, err := client.Connection.UpdateConnection(params)
+func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	_, err := client.Connection.updateConnection(d.Id(), getConnectionBody(d))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceConnectionRead(d, m)
+	for _, key := range connectionSecrets {
+		if d.HasChange(key) {
+			setSecretHashes(d, key)
+		}
+	}
+
+	if diags := checkConnection(client, d); diags.HasError() {
+		// keep the previous state so the next plan applies the change again rather than accepting a broken connection
+		d.Partial(true)
+		return diags
+	}
+
+	return resourceConnectionRead(ctx, d, m)
 }
 
-func resourceConnectionDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+// resourceConnectionValidateDialect checks the configured fields against the options the dialect supports. Only
+// fields set in the configuration are checked, so defaults never fail.
+func resourceConnectionValidateDialect(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, ok := m.(*Client)
+	if !ok || client == nil || !d.NewValueKnown("dialect_name") {
+		return nil
+	}
+
+	dialectName := d.Get("dialect_name").(string)
 
-	params := connection.NewDeleteConnectionParams()
-	params.ConnectionName = d.Id()
+	if _, err := client.dialects.all(); err != nil {
+		// the dialects can't be listed, e.g. for lack of permission, so the API validates the connection on apply
+		log.Printf("[WARN] Can't validate connection %s against its dialect: %s", d.Get("name").(string), err)
+		return nil
+	}
 
-	_, err := client.Connection.DeleteConnection(params)
+	// an unknown dialect, or one whose driver isn't installed, fails the plan
+	info, err := client.dialects.get(dialectName)
 	if err != nil {
 		return err
 	}
 
-	return nil
-}
+	config := d.GetRawConfig()
+	configured := func(field string) bool {
+		return !config.IsNull() && !config.GetAttr(field).IsNull()
+	}
 
-func resourceConnectionExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	client := m.(*apiclient.LookerAPI30Reference)
+	problems := []string{}
 
-	params := connection.NewConnectionParams()
-	params.ConnectionName = d.Id()
+	supported := dialectSupports(info)
+	fields := []string{}
+	for field := range supported {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
 
-	_, err := client.Connection.Connection(params)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
+	for _, field := range fields {
+		if !supported[field] && configured(field) {
+			problems = append(problems, fmt.Sprintf("%s: the %s dialect doesn't support this option", field, dialectName))
 		}
+	}
 
-		return false, err
+	// values only known on apply, e.g. interpolated from other resources, are left to the API
+	if supported["host"] && d.NewValueKnown("host") && d.Get("host").(string) == "" {
+		problems = append(problems, fmt.Sprintf("host: required by the %s dialect", dialectName))
 	}
 
-	return true, nil
+	usernameRequired := info.SupportedOptions != nil && info.SupportedOptions.UsernameRequired != nil && *info.SupportedOptions.UsernameRequired
+	if usernameRequired && d.NewValueKnown("uses_oauth") && !d.Get("uses_oauth").(bool) && d.NewValueKnown("username") && d.Get("username").(string) == "" {
+		problems = append(problems, fmt.Sprintf("username: required by the %s dialect", dialectName))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "\n"))
+	}
+
+	return nil
 }
 
-func resourceConnectionImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceConnectionRead(d, m); err != nil {
-		return nil, err
+func resourceConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	params := connection.NewDeleteConnectionParams()
+	params.ConnectionName = d.Id()
+
+	_, err := client.Connection.DeleteConnection(params)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	return []*schema.ResourceData{d}, nil
+
+	return nil
 }
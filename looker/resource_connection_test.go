@@ -0,0 +1,166 @@
+package looker
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerConnection(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(server, "connections", "looker_connection"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerConnectionConfig(server, "warehouse", "secret"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(server, "connections", "looker_connection.test"),
+					resource.TestCheckResourceAttr("looker_connection.test", "host", "warehouse"),
+					resource.TestCheckResourceAttr("looker_connection.test", "port", "5432"),
+					resource.TestMatchResourceAttr("looker_connection.test", "password", regexp.MustCompile("^"+secretHashPrefix)),
+				),
+			},
+			{
+				Config: testAccLookerConnectionConfig(server, "replica", "secret"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_connection.test", "host", "replica"),
+					testAccCheckRemoteAttr(server, "connections", "looker_connection.test", "host", "replica"),
+				),
+			},
+			{
+				// the update is applied, but the state keeps the previous host so the next plan tries again
+				Config:      testAccLookerConnectionConfig(server, "warehouse", lookertest.WrongPassword),
+				ExpectError: regexp.MustCompile("connect"),
+			},
+			{
+				Config:             testAccLookerConnectionConfig(server, "warehouse", lookertest.WrongPassword),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccLookerConnectionConfig(server, "replica", "rotated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRemoteAttr(server, "connections", "looker_connection.test", "host", "replica"),
+					testAccCheckRemoteAttr(server, "connections", "looker_connection.test", "password", "rotated"),
+				),
+			},
+			{
+				ResourceName:            "looker_connection.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "certificate", "test_on_apply"},
+			},
+		},
+	})
+}
+
+func testAccLookerConnectionConfig(server *lookertest.Server, host string, password string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_connection" "test" {
+  name         = "analytics"
+  dialect_name = "postgres"
+  host         = %q
+  database     = "analytics"
+  username     = "looker"
+  password     = %q
+
+  test_on_apply {}
+}
+`, host, password)
+}
+
+func TestAccLookerConnectionDialect(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: server.ProviderConfig() + `
+resource "looker_connection" "test" {
+  name         = "analytics"
+  dialect_name = "mysql"
+  host         = "warehouse"
+  database     = "analytics"
+  username     = "looker"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("the mysql dialect is not installed"),
+			},
+			{
+				Config: server.ProviderConfig() + `
+resource "looker_connection" "test" {
+  name         = "analytics"
+  dialect_name = "postgres"
+  database     = "analytics"
+  username     = "looker"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("host: required by the postgres dialect"),
+			},
+			{
+				// a host only known on apply isn't required at plan time
+				Config: server.ProviderConfig() + `
+resource "looker_group" "test" {
+  name = "Analysts"
+}
+
+resource "looker_connection" "test" {
+  name         = "analytics"
+  dialect_name = "postgres"
+  host         = "warehouse-${looker_group.test.id}"
+  database     = "analytics"
+  username     = "looker"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("looker_connection.test", "host", regexp.MustCompile(`^warehouse-\d+$`)),
+					testAccCheckRemoteAttr(server, "connections", "looker_connection.test", "ssl", "<nil>"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLookerConnectionUnmanagedFlags(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(server, "connections", "looker_connection"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerConnectionConfig(server, "warehouse", "secret"),
+			},
+			{
+				// flags set in the UI are kept when the configuration leaves them out
+				PreConfig: func() {
+					server.Update("connections", "analytics", map[string]interface{}{
+						"ssl":                        true,
+						"verify_ssl":                 true,
+						"sql_runner_precache_tables": false,
+						"user_db_credentials":        true,
+					})
+				},
+				Config:   testAccLookerConnectionConfig(server, "warehouse", "secret"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccLookerConnectionConfig(server, "replica", "secret"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_connection.test", "ssl", "true"),
+					testAccCheckRemoteAttr(server, "connections", "looker_connection.test", "host", "replica"),
+					testAccCheckRemoteAttr(server, "connections", "looker_connection.test", "ssl", "true"),
+					testAccCheckRemoteAttr(server, "connections", "looker_connection.test", "user_db_credentials", "true"),
+				),
+			},
+		},
+	})
+}
@@ -1,16 +1,16 @@
 package looker
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/billtrust/looker-go-sdk/client/content"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
 	"github.com/billtrust/looker-go-sdk/models"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // The GET resource for metadata content access does not exist, we need to search all the metadata content access for a specific contentmetadaid and than look for the group id.
@@ -18,12 +18,11 @@ import (
 // Since this is just access and nothing depends on it, I Am going to make this simpler by just implenting create, delete, read and skipping update
 func resourceContentMetadataAccess() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceContentMetadataAccessCreate,
-		Read:   resourceContentMetadataAccessRead,
-		Delete: resourceContentMetadataAccessDelete,
-		Exists: resourceContentMetadataAccessExists,
+		CreateContext: resourceContentMetadataAccessCreate,
+		ReadContext:   resourceContentMetadataAccessRead,
+		DeleteContext: resourceContentMetadataAccessDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceContentMetadataAccessImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -51,7 +50,7 @@ func resourceContentMetadataAccess() *schema.Resource {
 }
 
 func getContentMetadataAccess(m interface{}, contentMetadataID int64, groupID int64) (*models.ContentMetaGroupUser, error) {
-	client := m.(*apiclient.LookerAPI30Reference)
+	client := m.(*Client)
 
 	params := content.NewAllContentMetadataAccesssParams()
 	params.SetTimeout(time.Minute * 5)
@@ -68,20 +67,20 @@ func getContentMetadataAccess(m interface{}, contentMetadataID int64, groupID in
 		}
 	}
 
-	return nil, fmt.Errorf("Content Metadata Access Not found")
+	return nil, newNotFoundError("Content metadata access for group %d on content metadata %d not found", groupID, contentMetadataID)
 }
 
-func resourceContentMetadataAccessCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceContentMetadataAccessCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	groupID, err := getIDFromString(d.Get("group_id").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	contentMetadataID, err := getIDFromString(d.Get("content_metadata_id").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := content.NewCreateContentMetadataAccessParams()
@@ -93,13 +92,14 @@ func resourceContentMetadataAccessCreate(d *schema.ResourceData, m interface{})
 
 	result, err := client.Content.CreateContentMetadataAccess(params)
 	if err != nil {
-		if !strings.Contains(err.Error(), "already has access on content") {
-			return err
+		// the group may already have access, e.g. inherited from the parent space
+		if !isRejected(err) || !apiErrorContains(err, "already has access on content") {
+			return diag.FromErr(err)
 		}
 
 		access, err := getContentMetadataAccess(m, contentMetadataID, groupID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		d.SetId(getStringFromID(access.ContentMetadataID) + ":" + getStringFromID(access.GroupID))
@@ -107,13 +107,13 @@ func resourceContentMetadataAccessCreate(d *schema.ResourceData, m interface{})
 		d.SetId(getStringFromID(result.Payload.ContentMetadataID) + ":" + getStringFromID(result.Payload.GroupID))
 	}
 
-	return resourceContentMetadataAccessRead(d, m)
+	return resourceContentMetadataAccessRead(ctx, d, m)
 }
 
-func resourceContentMetadataAccessRead(d *schema.ResourceData, m interface{}) error {
+func resourceContentMetadataAccessRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id := strings.Split(d.Id(), ":")
 	if len(id) != 2 {
-		return fmt.Errorf("ID Should be two strings separated by a colon (:)")
+		return diag.Errorf("ID Should be two strings separated by a colon (:)")
 	}
 
 	sContentMetadataID := id[0]
@@ -121,21 +121,21 @@ func resourceContentMetadataAccessRead(d *schema.ResourceData, m interface{}) er
 
 	groupID, err := getIDFromString(sGroupID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	contentMetadataID, err := getIDFromString(sContentMetadataID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	access, err := getContentMetadataAccess(m, contentMetadataID, groupID)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("content_metadata_access_id", getStringFromID(access.ID))
@@ -146,10 +146,10 @@ func resourceContentMetadataAccessRead(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceContentMetadataAccessDelete(d *schema.ResourceData, m interface{}) error {
+func resourceContentMetadataAccessDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id := strings.Split(d.Id(), ":")
 	if len(id) != 2 {
-		return fmt.Errorf("ID Should be two strings separated by a colon (:)")
+		return diag.Errorf("ID Should be two strings separated by a colon (:)")
 	}
 
 	sContentMetadataID := id[0]
@@ -157,24 +157,24 @@ func resourceContentMetadataAccessDelete(d *schema.ResourceData, m interface{})
 
 	groupID, err := getIDFromString(sGroupID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	contentMetadataID, err := getIDFromString(sContentMetadataID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	access, err := getContentMetadataAccess(m, contentMetadataID, groupID)
 	if err != nil {
 		// if attempting to delete and it is already deleted say it was succesful
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
-	client := m.(*apiclient.LookerAPI30Reference)
+	client := m.(*Client)
 	params := content.NewDeleteContentMetadataAccessParams()
 	params.SetTimeout(time.Minute * 5)
 	params.ContentMetadataAccessID = access.ID
@@ -183,50 +183,12 @@ func resourceContentMetadataAccessDelete(d *schema.ResourceData, m interface{})
 	if err != nil {
 		// if the error is "Cannot remove access for [group_name] Group with edit on parent", I think the correct thing to do is ignore this error since the user already has edit on parent.
 		// When/if parent access is deleted, it deletes access on child (verified)
-		if strings.Contains(err.Error(), "with edit on parent") {
+		if isRejected(err) && apiErrorContains(err, "with edit on parent") {
 			log.Printf("[WARN] Deleting access from child does not work since it is inherited from parent., %s", err.Error())
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
-
-func resourceContentMetadataAccessExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	id := strings.Split(d.Id(), ":")
-	if len(id) != 2 {
-		return false, fmt.Errorf("ID Should be two strings separated by a colon (:)")
-	}
-	sContentMetadataID := id[0]
-	sGroupID := id[1]
-
-	groupID, err := getIDFromString(sGroupID)
-	if err != nil {
-		return false, err
-	}
-
-	contentMetadataID, err := getIDFromString(sContentMetadataID)
-	if err != nil {
-		return false, err
-	}
-
-	_, err = getContentMetadataAccess(m, contentMetadataID, groupID)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
-		}
-		return false, err
-	}
-
-	return true, nil
-}
-
-func resourceContentMetadataAccessImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceContentMetadataAccessRead(d, m); err != nil {
-		return nil, err
-	}
-	return []*schema.ResourceData{d}, nil
-}
@@ -0,0 +1,133 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/looker-go-sdk/client/content"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLookerContentMetadataAccess(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckContentMetadataAccessDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerContentMetadataAccessConfig(server, "view"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckContentMetadataAccess(server, "looker_content_metadata_access.test", "view"),
+					resource.TestCheckResourceAttrPair("looker_content_metadata_access.test", "content_metadata_id", "looker_folder.test", "content_metadata_id"),
+					resource.TestCheckResourceAttrPair("looker_content_metadata_access.test", "group_id", "looker_group.test", "id"),
+				),
+			},
+			{
+				Config: testAccLookerContentMetadataAccessConfig(server, "edit"),
+				Check:  testAccCheckContentMetadataAccess(server, "looker_content_metadata_access.test", "edit"),
+			},
+			{
+				ResourceName:      "looker_content_metadata_access.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// TestAccLookerContentMetadataAccessExisting adopts the access a group already has on the content
+func TestAccLookerContentMetadataAccessExisting(t *testing.T) {
+	server := testAccServer(t)
+	client := newTestClient(t, server)
+
+	var contentMetadataID, groupID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckContentMetadataAccessDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerContentMetadataAccessContentConfig(server),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceAttrValue("looker_folder.test", "content_metadata_id", &contentMetadataID),
+					testAccCheckResourceAttrValue("looker_group.test", "id", &groupID),
+				),
+			},
+			{
+				PreConfig: func() {
+					iContentMetadataID, _ := getIDFromString(contentMetadataID)
+					iGroupID, _ := getIDFromString(groupID)
+
+					params := content.NewCreateContentMetadataAccessParams()
+					params.Body = &models.ContentMetaGroupUser{ContentMetadataID: iContentMetadataID, GroupID: iGroupID, PermissionType: "view"}
+					if _, err := client.Content.CreateContentMetadataAccess(params); err != nil {
+						t.Fatalf("Can't give the group access: %s", err)
+					}
+				},
+				Config: testAccLookerContentMetadataAccessConfig(server, "view"),
+				Check:  testAccCheckContentMetadataAccess(server, "looker_content_metadata_access.test", "view"),
+			},
+		},
+	})
+}
+
+// testAccCheckContentMetadataAccess checks the access of a resource in the state is stored by the fake
+func testAccCheckContentMetadataAccess(server *lookertest.Server, name string, permissionType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s is not in the state", name)
+		}
+
+		id := rs.Primary.Attributes["content_metadata_access_id"]
+		access, ok := server.Get("content_metadata_access", id)
+		if !ok {
+			return fmt.Errorf("%s %s was not found", name, id)
+		}
+		if fmt.Sprint(access["permission_type"]) != permissionType {
+			return fmt.Errorf("%s %s has permission_type %v, expected %q", name, id, access["permission_type"], permissionType)
+		}
+		return nil
+	}
+}
+
+func testAccCheckContentMetadataAccessDestroy(server *lookertest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "looker_content_metadata_access" {
+				continue
+			}
+			if _, ok := server.Get("content_metadata_access", rs.Primary.Attributes["content_metadata_access_id"]); ok {
+				return fmt.Errorf("looker_content_metadata_access %s still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccLookerContentMetadataAccessContentConfig(server *lookertest.Server) string {
+	return server.ProviderConfig() + `
+resource "looker_group" "test" {
+  name = "Analysts"
+}
+
+resource "looker_folder" "test" {
+  name        = "Reports"
+  parent_name = "Shared"
+}
+`
+}
+
+func testAccLookerContentMetadataAccessConfig(server *lookertest.Server, permissionType string) string {
+	return testAccLookerContentMetadataAccessContentConfig(server) + fmt.Sprintf(`
+resource "looker_content_metadata_access" "test" {
+  group_id            = looker_group.test.id
+  content_metadata_id = looker_folder.test.content_metadata_id
+  permission_type     = %q
+}
+`, permissionType)
+}
@@ -0,0 +1,262 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/billtrust/looker-go-sdk/client/content"
+	"github.com/billtrust/looker-go-sdk/client/space"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// rootFolderNames are the folders every Looker instance creates, which can be referenced with parent_name
+var rootFolderNames = []string{"Shared", "Users", "Embed Groups", "Embed Users"}
+
+func resourceFolder() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFolderCreate,
+		ReadContext:   resourceFolderRead,
+		UpdateContext: resourceFolderUpdate,
+		DeleteContext: resourceFolderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceFolderImport,
+		},
+		CustomizeDiff: resourceFolderCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"parent_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"parent_id", "parent_name"},
+			},
+			"parent_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"parent_id", "parent_name"},
+				ValidateFunc: validation.StringInSlice(rootFolderNames, false),
+			},
+			"parent_content_metadata_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content_metadata_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content_metadata_inherits": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+// getFolderParentID returns the ID of the configured parent, looking up the root folder named by parent_name
+func getFolderParentID(d *schema.ResourceData, m interface{}) (int64, error) {
+	if name := d.Get("parent_name").(string); name != "" {
+		rootSpace, err := getRootSpace(d, m, name)
+		if err != nil {
+			return 0, err
+		}
+		return rootSpace.ID, nil
+	}
+
+	return getIDFromString(d.Get("parent_id").(string))
+}
+
+func updateFolderContentMetadataInherits(d *schema.ResourceData, m interface{}, contentMetadataID int64) error {
+	client := m.(*Client)
+
+	inherits := d.Get("content_metadata_inherits").(bool)
+
+	params := content.NewUpdateContentMetadataParams()
+	params.ContentMetadataID = contentMetadataID
+	params.Body = &models.ContentMeta{}
+	params.Body.Inherits = &inherits
+
+	_, err := client.Content.UpdateContentMetadata(params)
+	return err
+}
+
+func resourceFolderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	parentID, err := getFolderParentID(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := space.NewCreateSpaceParams()
+	params.Body = &models.Space{}
+	params.Body.Name = d.Get("name").(string)
+	params.Body.ParentID = &parentID
+
+	result, err := client.Space.CreateSpace(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(getStringFromID(result.Payload.ID))
+
+	if err := updateFolderContentMetadataInherits(d, m, result.Payload.ContentMetadataID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceFolderRead(ctx, d, m)
+}
+
+func resourceFolderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	ID, err := getIDFromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	folder, err := getSpaceByID(d, m, ID)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", folder.Name)
+	d.Set("content_metadata_id", getStringFromID(folder.ContentMetadataID))
+
+	if folder.ParentID == nil {
+		return diag.Errorf("Folder %d is a root folder, which can't be managed", ID)
+	}
+	d.Set("parent_id", getStringFromID(*folder.ParentID))
+
+	parent, err := getSpaceByID(d, m, *folder.ParentID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("parent_content_metadata_id", getStringFromID(parent.ContentMetadataID))
+
+	// parent_name is only kept while the folder is still in that root folder, so a move made outside of terraform
+	// shows up as a change
+	if d.Get("parent_name").(string) != "" {
+		if parent.ParentID == nil {
+			d.Set("parent_name", parent.Name)
+		} else {
+			d.Set("parent_name", "")
+		}
+	}
+
+	contentMetadataParams := content.NewContentMetadataParams()
+	contentMetadataParams.ContentMetadataID = folder.ContentMetadataID
+
+	contentMetadataResult, err := client.Content.ContentMetadata(contentMetadataParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("content_metadata_inherits", contentMetadataResult.Payload.Inherits)
+
+	return nil
+}
+
+func resourceFolderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	ID, err := getIDFromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("name", "parent_id", "parent_name") {
+		parentID, err := getFolderParentID(d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		params := space.NewUpdateSpaceParams()
+		params.SpaceID = ID
+		params.Body = &models.Space{}
+		params.Body.Name = d.Get("name").(string)
+		params.Body.ParentID = &parentID
+
+		_, err = client.Space.UpdateSpace(params)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("content_metadata_inherits") {
+		contentMetadataID, err := getIDFromString(d.Get("content_metadata_id").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := updateFolderContentMetadataInherits(d, m, contentMetadataID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceFolderRead(ctx, d, m)
+}
+
+func resourceFolderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	ID, err := getIDFromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := space.NewDeleteSpaceParams()
+	params.SpaceID = ID
+
+	_, err = client.Space.DeleteSpace(params)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceFolderImport fills in parent_name for folders in a root folder. Most of them were created with
+// looker_main_space, whose configurations reference the parent by name.
+func resourceFolderImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ID, err := getIDFromString(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := getSpaceByID(d, m, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if folder.ParentID != nil {
+		parent, err := getSpaceByID(d, m, *folder.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.ParentID == nil {
+			d.Set("parent_name", parent.Name)
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceFolderCustomizeDiff marks parent_id as unknown when the folder moves to another root folder
+func resourceFolderCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && d.HasChange("parent_name") && d.Get("parent_name").(string) != "" {
+		return d.SetNewComputed("parent_id")
+	}
+	return nil
+}
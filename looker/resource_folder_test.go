@@ -0,0 +1,62 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerFolder(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(server, "spaces", "looker_folder"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerFolderConfig(server, "Reports"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(server, "spaces", "looker_folder.test"),
+					resource.TestCheckResourceAttr("looker_folder.test", "name", "Reports"),
+					resource.TestCheckResourceAttrSet("looker_folder.test", "parent_id"),
+					resource.TestCheckResourceAttrSet("looker_folder.test", "content_metadata_id"),
+					testAccCheckExists(server, "spaces", "looker_folder.child"),
+					resource.TestCheckResourceAttrPair("looker_folder.child", "parent_id", "looker_folder.test", "id"),
+				),
+			},
+			{
+				Config: testAccLookerFolderConfig(server, "Quarterly Reports"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_folder.test", "name", "Quarterly Reports"),
+					testAccCheckRemoteAttr(server, "spaces", "looker_folder.test", "name", "Quarterly Reports"),
+				),
+			},
+			{
+				ResourceName:      "looker_folder.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "looker_folder.child",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccLookerFolderConfig(server *lookertest.Server, name string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_folder" "test" {
+  name        = %q
+  parent_name = "Shared"
+}
+
+resource "looker_folder" "child" {
+  name      = "Archive"
+  parent_id = looker_folder.test.id
+}
+`, name)
+}
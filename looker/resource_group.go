@@ -1,25 +1,24 @@
 package looker
 
 import (
-	"strings"
+	"context"
 
 	"github.com/billtrust/looker-go-sdk/client/group"
 
 	"github.com/billtrust/looker-go-sdk/models"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGroupCreate,
-		Read:   resourceGroupRead,
-		Update: resourceGroupUpdate,
-		Delete: resourceGroupDelete,
-		Exists: resourceGroupExists,
+		CreateContext: resourceGroupCreate,
+		ReadContext:   resourceGroupRead,
+		UpdateContext: resourceGroupUpdate,
+		DeleteContext: resourceGroupDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceGroupImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -31,8 +30,8 @@ func resourceGroup() *schema.Resource {
 	}
 }
 
-func resourceGroupCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	params := group.NewCreateGroupParams()
 	params.Body = &models.Group{}
@@ -40,20 +39,20 @@ func resourceGroupCreate(d *schema.ResourceData, m interface{}) error {
 
 	result, err := client.Group.CreateGroup(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(getStringFromID(result.Payload.ID))
 
-	return resourceGroupRead(d, m)
+	return resourceGroupRead(ctx, d, m)
 }
 
-func resourceGroupRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := group.NewGroupParams()
@@ -61,11 +60,11 @@ func resourceGroupRead(d *schema.ResourceData, m interface{}) error {
 
 	result, err := client.Group.Group(params)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", result.Payload.Name)
@@ -73,12 +72,12 @@ func resourceGroupRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := group.NewUpdateGroupParams()
@@ -88,18 +87,18 @@ func resourceGroupUpdate(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Group.UpdateGroup(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGroupRead(d, m)
+	return resourceGroupRead(ctx, d, m)
 }
 
-func resourceGroupDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := group.NewDeleteGroupParams()
@@ -107,40 +106,8 @@ func resourceGroupDelete(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Group.DeleteGroup(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
-
-func resourceGroupExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	client := m.(*apiclient.LookerAPI30Reference)
-
-	ID, err := getIDFromString(d.Id())
-	if err != nil {
-		return false, err
-	}
-
-	params := group.NewGroupParams()
-	params.GroupID = ID
-
-	_, err = client.Group.Group(params)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
-		}
-
-		return false, err
-	}
-
-	return true, nil
-}
-
-func resourceGroupImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceGroupRead(d, m); err != nil {
-		return nil, err
-	}
-	return []*schema.ResourceData{d}, nil
-}
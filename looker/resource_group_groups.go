@@ -0,0 +1,170 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/billtrust/looker-go-sdk/client/group"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGroupGroups() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupGroupsCreate,
+		ReadContext:   resourceGroupGroupsRead,
+		UpdateContext: resourceGroupGroupsUpdate,
+		DeleteContext: resourceGroupGroupsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func getGroupGroupIDs(groupID int64, client *Client) (*schema.Set, error) {
+	params := group.NewAllGroupGroupsParams()
+	params.GroupID = groupID
+
+	result, err := client.Group.AllGroupGroups(params)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs := schema.NewSet(schema.HashString, nil)
+	for _, g := range result.Payload {
+		groupIDs.Add(getStringFromID(g.ID))
+	}
+
+	return groupIDs, nil
+}
+
+// setGroupGroups makes groupIDs the groups included in the group, changing only the memberships that differ.
+// Included groups deleted in the meantime are no longer members, so their removal succeeds.
+func setGroupGroups(groupID int64, groupIDs *schema.Set, client *Client) error {
+	current, err := getGroupGroupIDs(groupID, client)
+	if err != nil {
+		return err
+	}
+
+	for _, sID := range current.Difference(groupIDs).List() {
+		ID, err := getIDFromString(sID.(string))
+		if err != nil {
+			return err
+		}
+
+		params := group.NewDeleteGroupFromGroupParams()
+		params.GroupID = groupID
+		params.DeletingGroupID = ID
+
+		_, err = client.Group.DeleteGroupFromGroup(params)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+
+	for _, sID := range groupIDs.Difference(current).List() {
+		ID, err := getIDFromString(sID.(string))
+		if err != nil {
+			return err
+		}
+
+		params := group.NewAddGroupGroupParams()
+		params.GroupID = groupID
+		params.Body = &models.GroupIDForGroupInclusion{}
+		params.Body.GroupID = ID
+
+		_, err = client.Group.AddGroupGroup(params)
+		if isNotFound(err) {
+			return newNotFoundError("Can't include group %d in group %d, either of them doesn't exist", ID, groupID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceGroupGroupsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	groupID, err := getIDFromString(d.Get("group_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setGroupGroups(groupID, d.Get("group_ids").(*schema.Set), client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("group_id").(string))
+
+	return resourceGroupGroupsRead(ctx, d, m)
+}
+
+func resourceGroupGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	groupID, err := getIDFromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	groupIDs, err := getGroupGroupIDs(groupID, client)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("group_id", d.Id())
+	d.Set("group_ids", groupIDs)
+
+	return nil
+}
+
+func resourceGroupGroupsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	groupID, err := getIDFromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setGroupGroups(groupID, d.Get("group_ids").(*schema.Set), client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGroupGroupsRead(ctx, d, m)
+}
+
+func resourceGroupGroupsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	groupID, err := getIDFromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = setGroupGroups(groupID, schema.NewSet(schema.HashString, nil), client)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
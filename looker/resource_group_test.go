@@ -0,0 +1,47 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerGroup(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(server, "groups", "looker_group"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerGroupConfig(server, "Analysts"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(server, "groups", "looker_group.test"),
+					resource.TestCheckResourceAttr("looker_group.test", "name", "Analysts"),
+				),
+			},
+			{
+				Config: testAccLookerGroupConfig(server, "Senior Analysts"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_group.test", "name", "Senior Analysts"),
+					testAccCheckRemoteAttr(server, "groups", "looker_group.test", "name", "Senior Analysts"),
+				),
+			},
+			{
+				ResourceName:      "looker_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccLookerGroupConfig(server *lookertest.Server, name string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_group" "test" {
+  name = %q
+}
+`, name)
+}
@@ -0,0 +1,110 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGroupUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupUserCreate,
+		ReadContext:   resourceGroupUserRead,
+		DeleteContext: resourceGroupUserDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func getGroupUserID(d *schema.ResourceData) (int64, string, error) {
+	id := strings.Split(d.Id(), ":")
+	if len(id) != 2 {
+		return 0, "", fmt.Errorf("Invalid id %q, expected <group_id>:<user_id>", d.Id())
+	}
+
+	groupID, err := getIDFromString(id[0])
+	if err != nil {
+		return 0, "", err
+	}
+
+	return groupID, id[1], nil
+}
+
+func resourceGroupUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	groupID, err := getIDFromString(d.Get("group_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	userID := d.Get("user_id").(string)
+	if err := addGroupUser(groupID, userID, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("group_id").(string) + ":" + userID)
+
+	return resourceGroupUserRead(ctx, d, m)
+}
+
+func resourceGroupUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	groupID, userID, err := getGroupUserID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	userIDs, err := getGroupUserIDs(groupID, client)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// also the case when the user was deleted
+	if !userIDs.Contains(userID) {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("group_id", getStringFromID(groupID))
+	d.Set("user_id", userID)
+
+	return nil
+}
+
+func resourceGroupUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	groupID, userID, err := getGroupUserID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := removeGroupUser(groupID, userID, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
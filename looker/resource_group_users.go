@@ -0,0 +1,182 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/billtrust/looker-go-sdk/client/group"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGroupUsers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupUsersCreate,
+		ReadContext:   resourceGroupUsersRead,
+		UpdateContext: resourceGroupUsersUpdate,
+		DeleteContext: resourceGroupUsersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func getGroupUserIDs(groupID int64, client *Client) (*schema.Set, error) {
+	params := group.NewAllGroupUsersParams()
+	params.GroupID = groupID
+
+	result, err := client.Group.AllGroupUsers(params)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := schema.NewSet(schema.HashString, nil)
+	for _, user := range result.Payload {
+		userIDs.Add(getStringFromID(user.ID))
+	}
+
+	return userIDs, nil
+}
+
+func addGroupUser(groupID int64, userID string, client *Client) error {
+	ID, err := getIDFromString(userID)
+	if err != nil {
+		return err
+	}
+
+	params := group.NewAddGroupUserParams()
+	params.GroupID = groupID
+	params.Body = &models.GroupIDForGroupUserInclusion{}
+	params.Body.UserID = ID
+
+	_, err = client.Group.AddGroupUser(params)
+	if isNotFound(err) {
+		return newNotFoundError("Can't add user %s to group %d, either of them doesn't exist", userID, groupID)
+	}
+	return err
+}
+
+// removeGroupUser removes a user from a group. Users deleted in the meantime are no longer members, so their
+// removal succeeds.
+func removeGroupUser(groupID int64, userID string, client *Client) error {
+	ID, err := getIDFromString(userID)
+	if err != nil {
+		return err
+	}
+
+	params := group.NewDeleteGroupUserParams()
+	params.GroupID = groupID
+	params.UserID = ID
+
+	_, err = client.Group.DeleteGroupUser(params)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// setGroupUsers makes userIDs the members of the group, changing only the memberships that differ
+func setGroupUsers(groupID int64, userIDs *schema.Set, client *Client) error {
+	current, err := getGroupUserIDs(groupID, client)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range current.Difference(userIDs).List() {
+		if err := removeGroupUser(groupID, userID.(string), client); err != nil {
+			return err
+		}
+	}
+
+	for _, userID := range userIDs.Difference(current).List() {
+		if err := addGroupUser(groupID, userID.(string), client); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceGroupUsersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	groupID, err := getIDFromString(d.Get("group_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setGroupUsers(groupID, d.Get("user_ids").(*schema.Set), client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("group_id").(string))
+
+	return resourceGroupUsersRead(ctx, d, m)
+}
+
+func resourceGroupUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	groupID, err := getIDFromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	userIDs, err := getGroupUserIDs(groupID, client)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("group_id", d.Id())
+	d.Set("user_ids", userIDs)
+
+	return nil
+}
+
+func resourceGroupUsersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	groupID, err := getIDFromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setGroupUsers(groupID, d.Get("user_ids").(*schema.Set), client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGroupUsersRead(ctx, d, m)
+}
+
+func resourceGroupUsersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	groupID, err := getIDFromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = setGroupUsers(groupID, schema.NewSet(schema.HashString, nil), client)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -1,28 +1,29 @@
 package looker
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/billtrust/looker-go-sdk/client/content"
 
 	"github.com/billtrust/looker-go-sdk/client/space"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
 	"github.com/billtrust/looker-go-sdk/models"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceMainSpace() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceMainSpaceCreate,
-		Read:   resourceMainSpaceRead,
-		Update: resourceMainSpaceUpdate,
-		Delete: resourceMainSpaceDelete,
-		Exists: resourceMainSpaceExists,
+		DeprecationMessage: "Use looker_folder instead, which handles both root parented and nested folders. Import the folder into a looker_folder resource and remove this one from the state.",
+
+		CreateContext: resourceMainSpaceCreate,
+		ReadContext:   resourceMainSpaceRead,
+		UpdateContext: resourceMainSpaceUpdate,
+		DeleteContext: resourceMainSpaceDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceMainSpaceImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -56,7 +57,7 @@ func resourceMainSpace() *schema.Resource {
 }
 
 func getRootSpace(d *schema.ResourceData, m interface{}, name string) (*models.Space, error) {
-	client := m.(*apiclient.LookerAPI30Reference)
+	client := m.(*Client)
 
 	params := space.NewSearchSpacesParams()
 	params.Name = &name
@@ -81,7 +82,7 @@ func getRootSpace(d *schema.ResourceData, m interface{}, name string) (*models.S
 }
 
 func getSpaceByID(d *schema.ResourceData, m interface{}, id int64) (*models.Space, error) {
-	client := m.(*apiclient.LookerAPI30Reference)
+	client := m.(*Client)
 
 	params := space.NewSpaceParams()
 	params.SpaceID = id
@@ -95,12 +96,12 @@ func getSpaceByID(d *schema.ResourceData, m interface{}, id int64) (*models.Spac
 	return result.Payload, nil
 }
 
-func resourceMainSpaceCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceMainSpaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	rootSpace, err := getRootSpace(d, m, d.Get("parent_space_name").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := space.NewCreateSpaceParams()
@@ -110,7 +111,7 @@ func resourceMainSpaceCreate(d *schema.ResourceData, m interface{}) error {
 
 	result, err := client.Space.CreateSpace(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(getStringFromID(result.Payload.ID))
@@ -124,27 +125,27 @@ func resourceMainSpaceCreate(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Content.UpdateContentMetadata(contentMetadataParams)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceMainSpaceRead(d, m)
+	return resourceMainSpaceRead(ctx, d, m)
 }
 
-func resourceMainSpaceRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceMainSpaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	space, err := getSpaceByID(d, m, ID)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", space.Name)
@@ -153,7 +154,7 @@ func resourceMainSpaceRead(d *schema.ResourceData, m interface{}) error {
 
 	parentSpace, err := getSpaceByID(d, m, *space.ParentID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("parent_space_name", parentSpace.Name)
@@ -164,7 +165,7 @@ func resourceMainSpaceRead(d *schema.ResourceData, m interface{}) error {
 
 	contentMetadataResult, err := client.Content.ContentMetadata(contentMetadataParams)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("content_metadata_inherits", contentMetadataResult.Payload.Inherits)
@@ -172,17 +173,17 @@ func resourceMainSpaceRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceMainSpaceUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceMainSpaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	rootSpace, err := getRootSpace(d, m, d.Get("parent_space_name").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := space.NewUpdateSpaceParams()
@@ -193,13 +194,13 @@ func resourceMainSpaceUpdate(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Space.UpdateSpace(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if d.HasChange("content_metadata_inherits") {
 		contentMetadataID, err := getIDFromString(d.Get("content_metadata_id").(string))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		contentMetadataInherts := d.Get("content_metadata_inherits").(bool)
@@ -214,19 +215,19 @@ func resourceMainSpaceUpdate(d *schema.ResourceData, m interface{}) error {
 
 		_, err = client.Content.UpdateContentMetadata(contentMetadataParams)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return resourceMainSpaceRead(d, m)
+	return resourceMainSpaceRead(ctx, d, m)
 }
 
-func resourceMainSpaceDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceMainSpaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := space.NewDeleteSpaceParams()
@@ -234,35 +235,8 @@ func resourceMainSpaceDelete(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Space.DeleteSpace(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
-
-func resourceMainSpaceExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	ID, err := getIDFromString(d.Id())
-	if err != nil {
-		return false, err
-	}
-
-	_, err = getSpaceByID(d, m, ID)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
-		}
-
-		return false, err
-	}
-
-	return true, nil
-}
-
-func resourceMainSpaceImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceMainSpaceRead(d, m); err != nil {
-		return nil, err
-	}
-	return []*schema.ResourceData{d}, nil
-}
@@ -0,0 +1,53 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerMainSpace(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(server, "spaces", "looker_main_space"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerMainSpaceConfig(server, "Reports", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(server, "spaces", "looker_main_space.test"),
+					resource.TestCheckResourceAttrSet("looker_main_space.test", "parent_id"),
+					resource.TestCheckResourceAttrSet("looker_main_space.test", "content_metadata_id"),
+					resource.TestCheckResourceAttr("looker_main_space.test", "content_metadata_inherits", "true"),
+				),
+			},
+			{
+				Config: testAccLookerMainSpaceConfig(server, "Team Reports", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRemoteAttr(server, "spaces", "looker_main_space.test", "name", "Team Reports"),
+					resource.TestCheckResourceAttr("looker_main_space.test", "content_metadata_inherits", "false"),
+				),
+			},
+			{
+				ResourceName:      "looker_main_space.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the parent is configured by name, which isn't read back
+				ImportStateVerifyIgnore: []string{"parent_space_name"},
+			},
+		},
+	})
+}
+
+func testAccLookerMainSpaceConfig(server *lookertest.Server, name string, inherits bool) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_main_space" "test" {
+  name                      = %q
+  parent_space_name         = "Shared"
+  content_metadata_inherits = %t
+}
+`, name, inherits)
+}
@@ -1,24 +1,23 @@
 package looker
 
 import (
-	"strings"
+	"context"
 
 	"github.com/billtrust/looker-go-sdk/client/role"
 	"github.com/billtrust/looker-go-sdk/models"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceModelSet() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceModelSetCreate,
-		Read:   resourceModelSetRead,
-		Update: resourceModelSetUpdate,
-		Delete: resourceModelSetDelete,
-		Exists: resourceModelSetExists,
+		CreateContext: resourceModelSetCreate,
+		ReadContext:   resourceModelSetRead,
+		UpdateContext: resourceModelSetUpdate,
+		DeleteContext: resourceModelSetDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceModelSetImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -35,8 +34,8 @@ func resourceModelSet() *schema.Resource {
 	}
 }
 
-func resourceModelSetCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceModelSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	var modelNames []string
 	for _, modelName := range d.Get("models").(*schema.Set).List() {
@@ -50,20 +49,20 @@ func resourceModelSetCreate(d *schema.ResourceData, m interface{}) error {
 
 	result, err := client.Role.CreateModelSet(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(getStringFromID(result.Payload.ID))
 
-	return resourceModelSetRead(d, m)
+	return resourceModelSetRead(ctx, d, m)
 }
 
-func resourceModelSetRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceModelSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := role.NewModelSetParams()
@@ -71,11 +70,11 @@ func resourceModelSetRead(d *schema.ResourceData, m interface{}) error {
 
 	result, err := client.Role.ModelSet(params)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", result.Payload.Name)
@@ -84,12 +83,12 @@ func resourceModelSetRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceModelSetUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceModelSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var modelNames []string
@@ -105,18 +104,18 @@ func resourceModelSetUpdate(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Role.UpdateModelSet(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceModelSetRead(d, m)
+	return resourceModelSetRead(ctx, d, m)
 }
 
-func resourceModelSetDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceModelSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := role.NewDeleteModelSetParams()
@@ -124,40 +123,8 @@ func resourceModelSetDelete(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Role.DeleteModelSet(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
-
-func resourceModelSetExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	client := m.(*apiclient.LookerAPI30Reference)
-
-	ID, err := getIDFromString(d.Id())
-	if err != nil {
-		return false, err
-	}
-
-	params := role.NewModelSetParams()
-	params.ModelSetID = ID
-
-	_, err = client.Role.ModelSet(params)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
-		}
-
-		return false, err
-	}
-
-	return true, nil
-}
-
-func resourceModelSetImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceModelSetRead(d, m); err != nil {
-		return nil, err
-	}
-	return []*schema.ResourceData{d}, nil
-}
@@ -0,0 +1,48 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerModelSet(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(server, "model_sets", "looker_model_set"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerModelSetConfig(server, `["sales", "marketing"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(server, "model_sets", "looker_model_set.test"),
+					resource.TestCheckResourceAttr("looker_model_set.test", "models.#", "2"),
+				),
+			},
+			{
+				Config: testAccLookerModelSetConfig(server, `["sales"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_model_set.test", "models.#", "1"),
+					testAccCheckRemoteAttr(server, "model_sets", "looker_model_set.test", "models", "[sales]"),
+				),
+			},
+			{
+				ResourceName:      "looker_model_set.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccLookerModelSetConfig(server *lookertest.Server, models string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_model_set" "test" {
+  name   = "Sales"
+  models = %s
+}
+`, models)
+}
@@ -1,25 +1,25 @@
 package looker
 
 import (
-	"strings"
+	"context"
 
 	"github.com/billtrust/looker-go-sdk/client/role"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
 	"github.com/billtrust/looker-go-sdk/models"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourcePermissionSet() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePermissionSetCreate,
-		Read:   resourcePermissionSetRead,
-		Update: resourcePermissionSetUpdate,
-		Delete: resourcePermissionSetDelete,
-		Exists: resourcePermissionSetExists,
+		CreateContext: resourcePermissionSetCreate,
+		ReadContext:   resourcePermissionSetRead,
+		UpdateContext: resourcePermissionSetUpdate,
+		DeleteContext: resourcePermissionSetDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourcePermissionSetImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourcePermissionSetValidatePermissions,
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -35,8 +35,8 @@ func resourcePermissionSet() *schema.Resource {
 	}
 }
 
-func resourcePermissionSetCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourcePermissionSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	var permissions []string
 	for _, permission := range d.Get("permissions").(*schema.Set).List() {
@@ -48,22 +48,24 @@ func resourcePermissionSetCreate(d *schema.ResourceData, m interface{}) error {
 	params.Body.Name = d.Get("name").(string)
 	params.Body.Permissions = permissions
 
+	diags := client.permissions.warnings(permissions)
+
 	result, err := client.Role.CreatePermissionSet(params)
 	if err != nil {
-		return err
+		return append(diags, diag.FromErr(err)...)
 	}
 
 	d.SetId(getStringFromID(result.Payload.ID))
 
-	return resourcePermissionSetRead(d, m)
+	return append(diags, resourcePermissionSetRead(ctx, d, m)...)
 }
 
-func resourcePermissionSetRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourcePermissionSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := role.NewPermissionSetParams()
@@ -71,11 +73,11 @@ func resourcePermissionSetRead(d *schema.ResourceData, m interface{}) error {
 
 	result, err := client.Role.PermissionSet(params)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", result.Payload.Name)
@@ -84,12 +86,12 @@ func resourcePermissionSetRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourcePermissionSetUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourcePermissionSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var permissions []string
@@ -103,20 +105,22 @@ func resourcePermissionSetUpdate(d *schema.ResourceData, m interface{}) error {
 	params.Body.Name = d.Get("name").(string)
 	params.Body.Permissions = permissions
 
+	diags := client.permissions.warnings(permissions)
+
 	_, err = client.Role.UpdatePermissionSet(params)
 	if err != nil {
-		return err
+		return append(diags, diag.FromErr(err)...)
 	}
 
-	return resourcePermissionSetRead(d, m)
+	return append(diags, resourcePermissionSetRead(ctx, d, m)...)
 }
 
-func resourcePermissionSetDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourcePermissionSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := role.NewDeletePermissionSetParams()
@@ -124,40 +128,8 @@ func resourcePermissionSetDelete(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Role.DeletePermissionSet(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
-
-func resourcePermissionSetExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	client := m.(*apiclient.LookerAPI30Reference)
-
-	ID, err := getIDFromString(d.Id())
-	if err != nil {
-		return false, err
-	}
-
-	params := role.NewPermissionSetParams()
-	params.PermissionSetID = ID
-
-	_, err = client.Role.PermissionSet(params)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
-		}
-
-		return false, err
-	}
-
-	return true, nil
-}
-
-func resourcePermissionSetImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourcePermissionSetRead(d, m); err != nil {
-		return nil, err
-	}
-	return []*schema.ResourceData{d}, nil
-}
@@ -0,0 +1,56 @@
+package looker
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerPermissionSet(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(server, "permission_sets", "looker_permission_set"),
+		Steps: []resource.TestStep{
+			{
+				Config:   testAccLookerPermissionSetConfig(server, true, "acess_data"),
+				PlanOnly: true,
+				// the error points at the permissions in the configuration
+				ExpectError: regexp.MustCompile(`(?s)"acess_data" is not a permission.*permissions += \["see_looks", "acess_data"\]`),
+			},
+			{
+				Config: testAccLookerPermissionSetConfig(server, false, "access_data"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(server, "permission_sets", "looker_permission_set.test"),
+					resource.TestCheckResourceAttr("looker_permission_set.test", "permissions.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "looker_permission_set.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccLookerPermissionSetConfig(server *lookertest.Server, strict bool, permission string) string {
+	return fmt.Sprintf(`
+provider "looker" {
+  base_url      = %q
+  client_id     = %q
+  client_secret = %q
+
+  strict_permission_validation = %t
+}
+
+resource "looker_permission_set" "test" {
+  name        = "Analysts"
+  permissions = ["see_looks", %q]
+}
+`, server.BaseURL(), lookertest.ClientID, lookertest.ClientSecret, strict, permission)
+}
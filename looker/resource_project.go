@@ -1,33 +1,39 @@
 package looker
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/billtrust/looker-go-sdk/client/project"
-
-	apiclient "github.com/billtrust/looker-go-sdk/client"
 	"github.com/billtrust/looker-go-sdk/models"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/go-openapi/swag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func getProject(projectID string, client *apiclient.LookerAPI30Reference) (*project.ProjectOK, error) {
-	params := project.NewProjectParams()
-	params.ProjectID = projectID
+// getProject reads a project in dev mode, where projects that were never deployed to production exist too
+func getProject(projectID string, client *Client) (*lookerProject, error) {
+	var result *lookerProject
+	err := client.withWorkspace(workspaceDev, func() error {
+		var err error
+		result, err = client.Project.getProject(projectID)
+		return err
+	})
 
-	result, err := client.Project.Project(params)
 	return result, err
 }
 
+var pullRequestModes = []string{"off", "links", "recommended", "required"}
+
 func resourceProject() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceProjectCreate,
-		Read:   resourceProjectRead,
-		Update: resourceProjectUpdate,
-		Delete: resourceProjectDelete,
-		Exists: resourceProjectExists,
+		CreateContext: resourceProjectCreate,
+		ReadContext:   resourceProjectRead,
+		UpdateContext: resourceProjectUpdate,
+		DeleteContext: resourceProjectDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceProjectImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -42,124 +48,242 @@ func resourceProject() *schema.Resource {
 					return
 				},
 			},
+			"pull_request_mode": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validateOneOf("pull request mode", pullRequestModes),
+			},
+			"validation_required": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			// only applies when validation_required is true
+			"allow_warnings": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"git_release_mgmt_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			// generates the deploy key with the project, so it can be added to the repository before
+			// looker_project_git_details points the project to it
+			"generate_git_deploy_key": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ssh_deploy_key": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"uses_git": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"is_example": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			// Looker can't delete projects. On destroy, they are left as is, or with tombstone_on_destroy detached
+			// from their git repository and renamed to tombstone_name_prefix + name + a timestamp.
+			"tombstone_on_destroy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"tombstone_name_prefix": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "deleted_",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if strings.Contains(v, " ") {
+						errs = append(errs, fmt.Errorf("%q must not contain any spaces, got: %q", key, v))
+					}
+					return
+				},
+			},
 		},
 	}
 }
 
-func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+// getProjectBody returns the settings of the project configured in d, leaving the git details to
+// looker_project_git_details. Settings left out of the configuration are left to Looker: its default on create, their
+// current value on update.
+func getProjectBody(d *schema.ResourceData) *lookerProject {
+	config := d.GetRawConfig()
+	configured := func(key string) bool {
+		return !config.IsNull() && !config.GetAttr(key).IsNull()
+	}
+	configuredBool := func(key string) *bool {
+		if !configured(key) {
+			return nil
+		}
+		return swag.Bool(d.Get(key).(bool))
+	}
+
+	body := &lookerProject{Project: &models.Project{}}
+	body.Name = d.Get("name").(string)
+	if configured("pull_request_mode") {
+		body.PullRequestMode = d.Get("pull_request_mode").(string)
+	}
+	body.ValidationRequired = configuredBool("validation_required")
+	body.AllowWarnings = configuredBool("allow_warnings")
+	body.GitReleaseMgmtEnabled = configuredBool("git_release_mgmt_enabled")
 
-	err := updateSession(client, "dev")
-	if err != nil {
+	return body
+}
+
+// ensureGitDeployKey generates the deploy key of a project unless it has one
+func ensureGitDeployKey(projectID string, client *Client) error {
+	_, err := getGitDeployKey(projectID, client)
+	if err == nil || !isNotFound(err) {
 		return err
 	}
 
+	return createGitDeployKey(projectID, client)
+}
+
+func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
 	name := d.Get("name").(string)
-	params := project.NewCreateProjectParams()
-	params.Body = &models.Project{}
-	params.Body.Name = name
 
-	_, err = client.Project.CreateProject(params)
-	if err != nil {
+	err := client.withWorkspace(workspaceDev, func() error {
+		_, err := client.Project.createProject(getProjectBody(d))
 		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	d.SetId(name)
 
-	return resourceProjectRead(d, m)
-}
+	if d.Get("generate_git_deploy_key").(bool) {
+		if err := createGitDeployKey(name, client); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
-func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+	return resourceProjectRead(ctx, d, m)
+}
 
-	params := project.NewProjectParams()
-	params.ProjectID = d.Id()
+func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
-	result, err := client.Project.Project(params)
+	result, err := getProject(d.Id(), client)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
-	d.Set("name", result.Payload.Name)
+	d.Set("name", result.Name)
+	d.Set("pull_request_mode", result.PullRequestMode)
+	d.Set("validation_required", swag.BoolValue(result.ValidationRequired))
+	d.Set("allow_warnings", swag.BoolValue(result.AllowWarnings))
+	d.Set("git_release_mgmt_enabled", swag.BoolValue(result.GitReleaseMgmtEnabled))
+	d.Set("uses_git", result.UsesGit != nil && *result.UsesGit)
+	d.Set("is_example", result.IsExample != nil && *result.IsExample)
+
+	sshDeployKey := ""
+	if d.Get("generate_git_deploy_key").(bool) {
+		key, err := getGitDeployKey(d.Id(), client)
+		if err != nil && !isNotFound(err) {
+			return diag.FromErr(err)
+		}
+		if err == nil {
+			sshDeployKey = key.publicKey()
+		}
+	}
+	d.Set("ssh_deploy_key", sshDeployKey)
 
 	return nil
 }
 
-func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
-
-	err := updateSession(client, "dev")
-	if err != nil {
-		return err
-	}
+func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	name := d.Get("name").(string)
 
-	params := project.NewUpdateProjectParams()
-	params.ProjectID = d.Id()
-	params.Body = &models.Project{}
-	params.Body.Name = name
+	if d.HasChange("generate_git_deploy_key") && d.Get("generate_git_deploy_key").(bool) {
+		if err := ensureGitDeployKey(d.Id(), client); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
-	_, err = client.Project.UpdateProject(params)
+	err := client.withWorkspace(workspaceDev, func() error {
+		_, err := client.Project.updateProject(d.Id(), getProjectBody(d))
+		return err
+	})
 	if err != nil {
 		// looker gives "An error has occured" 500 error even though the name correctly is updated
-		if !strings.Contains(err.Error(), "An error has occurred.") {
-			return err
+		if !isServerError(err) {
+			return diag.FromErr(err)
 		}
 
 		// the project might be updated correctly, check by getting the project with the new name
 		_, readError := getProject(name, client)
 		if readError != nil {
-			return err // return original error for the update
+			return diag.FromErr(err) // return original error for the update
 		}
 
 		// looks like update of the name was succesful because we can query for it now.  Update the ID to be the new name and read it back
 		d.SetId(name)
 
-		return resourceProjectRead(d, m)
+		return resourceProjectRead(ctx, d, m)
 	}
 
-	return resourceProjectRead(d, m)
-}
-
-func resourceProjectDelete(d *schema.ResourceData, m interface{}) error {
-	// TODO: Looker doesn't appear to support deleting projects from the API
-	return nil
+	return resourceProjectRead(ctx, d, m)
 }
 
-func resourceProjectExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	client := m.(*apiclient.LookerAPI30Reference)
-
-	// TODO Not sure if we should always set session to "dev" instead of "production" when checking if it exists? will dev always show all dev+prod projects?
-	err := updateSession(client, "dev")
-	if err != nil {
-		return false, err
+// resourceProjectDelete tombstones the project if configured to, as Looker doesn't support deleting projects from
+// the API: the project is detached from its git repository and renamed to free its name. Either way, the project is
+// left in Looker, which a warning tells.
+func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	if !d.Get("tombstone_on_destroy").(bool) {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Project %s was not deleted", d.Id()),
+			Detail:   "Looker's API can't delete projects, so the project was only removed from the Terraform state. Delete it in Looker, or set tombstone_on_destroy to have it renamed and detached from its git repository on destroy.",
+		}}
 	}
 
-	params := project.NewProjectParams()
-	params.ProjectID = d.Id()
+	tombstone := d.Get("tombstone_name_prefix").(string) + d.Id() + "_" + time.Now().UTC().Format("20060102150405")
 
-	_, err = client.Project.Project(params)
+	err := client.withWorkspace(workspaceDev, func() error {
+		if _, err := client.Project.updateProject(d.Id(), &projectGitDetails{GitPassword: new(string)}); err != nil {
+			return err
+		}
+		_, err := client.Project.updateProject(d.Id(), &lookerProject{Project: &models.Project{Name: tombstone}})
+		return err
+	})
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
+		if isNotFound(err) {
+			return nil
 		}
 
-		return false, err
+		// as when updating, looker may give a 500 error even though the project was renamed
+		if !isServerError(err) {
+			return diag.FromErr(err)
+		}
+		if _, readError := getProject(tombstone, client); readError != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	return true, nil
-}
-
-func resourceProjectImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceProjectRead(d, m); err != nil {
-		return nil, err
-	}
-	return []*schema.ResourceData{d}, nil
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Project %s was renamed to %s instead of being deleted", d.Id(), tombstone),
+		Detail:   "Looker's API can't delete projects, so the project was detached from its git repository and renamed. Delete it in Looker to remove it.",
+	}}
 }
@@ -0,0 +1,209 @@
+package looker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/billtrust/looker-go-sdk/client/project"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceProjectGitDeployKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectGitDeployKeyCreate,
+		ReadContext:   resourceProjectGitDeployKeyRead,
+		UpdateContext: resourceProjectGitDeployKeyUpdate,
+		DeleteContext: resourceProjectGitDeployKeyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceProjectGitDeployKeyRotate,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// arbitrary values which regenerate the key when they change, e.g. a date to rotate it
+			"keepers": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// the public key, as "<key type> <key>"
+			"ssh_deploy_key": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"key_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// the SHA256 fingerprint, as shown by ssh-keygen -l and git hosts, e.g. "SHA256:..."
+			"fingerprint": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceGitDeployKey is looker_git_deploy_key, the former name of looker_project_git_deploy_key
+func resourceGitDeployKey() *schema.Resource {
+	r := resourceProjectGitDeployKey()
+	r.DeprecationMessage = "Use looker_project_git_deploy_key instead. Import the key into a looker_project_git_deploy_key resource and remove this one from the state, which leaves the key as is."
+	return r
+}
+
+// gitDeployKeyFields are the fields read from the key, which change when it's regenerated
+var gitDeployKeyFields = []string{"ssh_deploy_key", "key_type", "comment", "fingerprint"}
+
+type gitDeployKey struct {
+	keyType     string
+	key         string
+	comment     string
+	fingerprint string
+}
+
+// publicKey returns the key as it's added to git hosts, without its comment
+func (k *gitDeployKey) publicKey() string {
+	return k.keyType + " " + k.key
+}
+
+// parseGitDeployKey parses a deploy key payload, which is a public key in the authorized_keys format: the key type,
+// e.g. "ssh-rsa", the base64 encoded key and a comment, usually naming the project.
+func parseGitDeployKey(payload string) (*gitDeployKey, error) {
+	fields := strings.Fields(strings.Trim(payload, "\" \r\n"))
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("Unexpected git deploy key %q, expected a key type followed by the key", payload)
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("Unexpected git deploy key %q, the key isn't base64 encoded: %s", payload, err)
+	}
+
+	sum := sha256.Sum256(blob)
+
+	return &gitDeployKey{
+		keyType:     fields[0],
+		key:         fields[1],
+		comment:     strings.Join(fields[2:], " "),
+		fingerprint: "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]),
+	}, nil
+}
+
+// getGitDeployKey returns the deploy key of a project, with the error of the API or of parsing the key
+func getGitDeployKey(projectID string, client *Client) (*gitDeployKey, error) {
+	params := project.NewGitDeployKeyParams()
+	params.ProjectID = projectID
+
+	var result *project.GitDeployKeyOK
+	err := client.withWorkspace(workspaceDev, func() error {
+		var err error
+		result, err = client.Project.GitDeployKey(params)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseGitDeployKey(result.Payload)
+}
+
+// createGitDeployKey generates a new deploy key for a project, replacing its current one
+func createGitDeployKey(projectID string, client *Client) error {
+	params := project.NewCreateGitDeployKeyParams()
+	params.ProjectID = projectID
+
+	return client.withWorkspace(workspaceDev, func() error {
+		_, err := client.Project.CreateGitDeployKey(params)
+		return err
+	})
+}
+
+func resourceProjectGitDeployKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	projectID := d.Get("project_id").(string)
+
+	err := createGitDeployKey(projectID, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(projectID)
+
+	return resourceProjectGitDeployKeyRead(ctx, d, m)
+}
+
+func resourceProjectGitDeployKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	projectID := d.Id()
+
+	key, err := getGitDeployKey(projectID, client)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("project_id", projectID)
+	d.Set("ssh_deploy_key", key.publicKey())
+	d.Set("key_type", key.keyType)
+	d.Set("comment", key.comment)
+	d.Set("fingerprint", key.fingerprint)
+
+	return nil
+}
+
+// resourceProjectGitDeployKeyUpdate regenerates the key when the keepers change
+func resourceProjectGitDeployKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	if d.HasChange("keepers") {
+		if err := createGitDeployKey(d.Id(), client); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceProjectGitDeployKeyRead(ctx, d, m)
+}
+
+// resourceProjectGitDeployKeyDelete only warns, as there is no way to delete a git deploy key
+func resourceProjectGitDeployKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("The git deploy key of project %s was not deleted", d.Id()),
+		Detail:   "Looker's API can't delete deploy keys, so the key was only removed from the Terraform state and Looker keeps using it. Remove it from the git repository to revoke its access.",
+	}}
+}
+
+// resourceProjectGitDeployKeyRotate plans a new key when the keepers of an existing one change
+func resourceProjectGitDeployKeyRotate(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("keepers") {
+		return nil
+	}
+
+	for _, key := range gitDeployKeyFields {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package looker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	testDeployKey            = "AAAAC3NzaC1lZDI1NTE5AAAAIMxVTwGbhT/JWCmGBaqdPSVtdbMjXykKxacdXBDPWjqp"
+	testDeployKeyFingerprint = "SHA256:wbTL0uzMYhPEUVs32c1UoPom9zv0TpvFFtwcDTITn7s"
+)
+
+func TestParseGitDeployKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    *gitDeployKey
+		wantErr string
+	}{
+		{
+			"key",
+			"ssh-ed25519 " + testDeployKey,
+			&gitDeployKey{keyType: "ssh-ed25519", key: testDeployKey, fingerprint: testDeployKeyFingerprint},
+			"",
+		},
+		{
+			"comment",
+			"ssh-ed25519 " + testDeployKey + " looker@analytics",
+			&gitDeployKey{keyType: "ssh-ed25519", key: testDeployKey, comment: "looker@analytics", fingerprint: testDeployKeyFingerprint},
+			"",
+		},
+		{
+			"comment with spaces",
+			"ssh-ed25519 " + testDeployKey + " Looker  analytics project",
+			&gitDeployKey{keyType: "ssh-ed25519", key: testDeployKey, comment: "Looker analytics project", fingerprint: testDeployKeyFingerprint},
+			"",
+		},
+		{
+			"extra whitespace",
+			"\r\n  ssh-ed25519\t" + testDeployKey + "   looker@analytics \n",
+			&gitDeployKey{keyType: "ssh-ed25519", key: testDeployKey, comment: "looker@analytics", fingerprint: testDeployKeyFingerprint},
+			"",
+		},
+		{
+			"json string",
+			`"ssh-ed25519 ` + testDeployKey + ` looker@analytics"`,
+			&gitDeployKey{keyType: "ssh-ed25519", key: testDeployKey, comment: "looker@analytics", fingerprint: testDeployKeyFingerprint},
+			"",
+		},
+		{"empty", "", nil, "expected a key type followed by the key"},
+		{"whitespace", " \r\n", nil, "expected a key type followed by the key"},
+		{"missing key type", testDeployKey, nil, "expected a key type followed by the key"},
+		{"missing key type with comment", testDeployKey + " looker@analytics", nil, "the key isn't base64 encoded"},
+		{"invalid key", "ssh-ed25519 not-a-key", nil, "the key isn't base64 encoded"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseGitDeployKey(test.payload)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Errorf("got error %v, want one containing %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != *test.want {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAccLookerProjectGitDeployKey(t *testing.T) {
+	server := testAccServer(t)
+
+	var key string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerProjectGitDeployKeyConfig(server, "2026-01"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_project_git_deploy_key.test", "id", "analytics"),
+					resource.TestCheckResourceAttr("looker_project_git_deploy_key.test", "key_type", "ssh-rsa"),
+					resource.TestCheckResourceAttr("looker_project_git_deploy_key.test", "comment", "looker@analytics"),
+					testAccCheckResourceAttrValue("looker_project_git_deploy_key.test", "ssh_deploy_key", &key),
+				),
+			},
+			{
+				// the same keepers keep the key
+				Config:   testAccLookerProjectGitDeployKeyConfig(server, "2026-01"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccLookerProjectGitDeployKeyConfig(server, "2026-07"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceAttrChanged("looker_project_git_deploy_key.test", "ssh_deploy_key", &key),
+					resource.TestCheckResourceAttr("looker_project_git_deploy_key.test", "id", "analytics"),
+				),
+			},
+			{
+				ResourceName:            "looker_project_git_deploy_key.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"keepers"},
+			},
+		},
+	})
+}
+
+func testAccLookerProjectGitDeployKeyConfig(server *lookertest.Server, rotation string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_project" "test" {
+  name = "analytics"
+}
+
+resource "looker_project_git_deploy_key" "test" {
+  project_id = looker_project.test.id
+
+  keepers = {
+    rotation = %q
+  }
+}
+`, rotation)
+}
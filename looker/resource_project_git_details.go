@@ -1,152 +1,237 @@
 package looker
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/billtrust/looker-go-sdk/client/project"
 	"github.com/billtrust/looker-go-sdk/models"
-	"github.com/go-openapi/strfmt"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceProjectGitDetails() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceProjectGitDetailsCreate,
-		Read:   resourceProjectGitDetailsRead,
-		Delete: resourceProjectGitDetailsDelete,
-		Update: resourceProjectGitDetailsDelete,
-		Exists: resourceProjectGitDetailsExists,
+		CreateContext: resourceProjectGitDetailsCreate,
+		ReadContext:   resourceProjectGitDetailsRead,
+		UpdateContext: resourceProjectGitDetailsUpdate,
+		DeleteContext: resourceProjectGitDetailsDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceProjectGitDetailsImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
 			"project_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"git_remote_url": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			// credentials for HTTPS remotes. With user attributes each developer uses their own, and git_username
+			// and git_password are only used in production.
+			"git_username": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// stored as a salted hash, see secrets.go
+			"git_password": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressSecretDiff,
+			},
+			"git_username_user_attribute": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"git_password_user_attribute": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// detected by Looker from the remote when not set, e.g. "github"
+			"git_service_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			// runs Looker's git connection tests after every create and update, failing the apply if the remote
+			// isn't reachable
+			"test_connection": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
 		},
 	}
 }
 
 func setProjectGitDetails(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+	client := m.(*Client)
+
+	body := &projectGitDetails{
+		GitRemoteURL:             d.Get("git_remote_url").(string),
+		GitUsername:              d.Get("git_username").(string),
+		GitUsernameUserAttribute: d.Get("git_username_user_attribute").(string),
+		GitPasswordUserAttribute: d.Get("git_password_user_attribute").(string),
+		GitServiceName:           d.Get("git_service_name").(string),
+	}
+	if d.HasChange("git_password") {
+		gitPassword := d.Get("git_password").(string)
+		body.GitPassword = &gitPassword
+	}
 
-	err := updateSession(client, "dev")
+	err := client.withWorkspace(workspaceDev, func() error {
+		_, err := client.Project.updateProject(d.Get("project_id").(string), body)
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	gitRemoteURL := strfmt.URI(d.Get("git_remote_url").(string))
-
-	projectID := d.Get("project_id").(string)
-	params := project.NewUpdateProjectParams()
-	params.ProjectID = projectID
-	params.Body = &models.Project{}
-	params.Body.GitRemoteURL = &gitRemoteURL
-
-	_, err = client.Project.UpdateProject(params)
-	if err != nil {
-		return err
+	if d.HasChange("git_password") {
+		setSecretHashes(d, "git_password")
 	}
 
 	return nil
 }
 
-func resourceProjectGitDetailsCreate(d *schema.ResourceData, m interface{}) error {
-	err := setProjectGitDetails(d, m)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			d.SetId("")
-			return nil
+// testProjectGitConnection runs the git connection tests of a project in order, as Looker does, stopping at the first
+// one failing. It fails with the results of the tests run then.
+func testProjectGitConnection(projectID string, client *Client) diag.Diagnostics {
+	results := []*models.GitConnectionTestResult{}
+	failed := false
+
+	err := client.withWorkspace(workspaceDev, func() error {
+		params := project.NewAllGitConnectionTestsParams()
+		params.ProjectID = projectID
+
+		tests, err := client.Project.AllGitConnectionTests(params)
+		if err != nil {
+			return err
 		}
 
-		return err
+		for _, test := range tests.Payload {
+			params := project.NewRunGitConnectionTestParams()
+			params.ProjectID = projectID
+			params.TestID = test.ID
+
+			result, err := client.Project.RunGitConnectionTest(params)
+			if err != nil {
+				return err
+			}
+
+			results = append(results, result.Payload)
+			if result.Payload.Status != "pass" {
+				failed = true
+				return nil
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return diag.Errorf("Can't test the git connection of project %s: %s", projectID, err)
 	}
 
-	d.SetId(d.Get("project_id").(string))
+	if !failed {
+		return nil
+	}
 
-	return resourceProjectGitDetailsRead(d, m)
-}
+	lines := []string{}
+	for _, result := range results {
+		line := fmt.Sprintf("%s: %s", result.ID, result.Status)
+		if result.Message != "" {
+			line += " - " + result.Message
+		}
+		lines = append(lines, line)
+	}
 
-func resourceProjectGitDetailsRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Project %s can't reach its git repository", projectID),
+		Detail:   strings.Join(lines, "\n"),
+	}}
+}
 
-	err := updateSession(client, "dev")
+func resourceProjectGitDetailsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	err := setProjectGitDetails(d, m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	projectID := d.Id()
+	d.SetId(d.Get("project_id").(string))
 
-	params := project.NewProjectParams()
-	params.ProjectID = projectID
+	if d.Get("test_connection").(bool) {
+		if diags := testProjectGitConnection(d.Id(), m.(*Client)); diags.HasError() {
+			return diags
+		}
+	}
 
-	result, err := client.Project.Project(params)
+	return resourceProjectGitDetailsRead(ctx, d, m)
+}
+
+func resourceProjectGitDetailsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	result, err := getProject(d.Id(), client)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
-	d.Set("project_id", result.Payload.ID)
-	d.Set("git_remote_url", result.Payload.GitRemoteURL.String())
-
-	return nil
-}
-
-func resourceProjectGitDetailsUpdate(d *schema.ResourceData, m interface{}) error {
-	err := setProjectGitDetails(d, m)
-	if err != nil {
-		return err
+	gitRemoteURL := ""
+	if result.GitRemoteURL != nil {
+		gitRemoteURL = result.GitRemoteURL.String()
 	}
 
-	return resourceProjectGitDetailsRead(d, m)
-}
+	// git_password is never returned
+	d.Set("project_id", result.ID)
+	d.Set("git_remote_url", gitRemoteURL)
+	d.Set("git_username", result.GitUsername)
+	d.Set("git_username_user_attribute", result.GitUsernameUserAttribute)
+	d.Set("git_password_user_attribute", result.GitPasswordUserAttribute)
+	d.Set("git_service_name", result.GitServiceName)
 
-func resourceProjectGitDetailsDelete(d *schema.ResourceData, m interface{}) error {
-	// TODO: Deleting this resource should set the git fields back to blank values. not implementing this yet since leaving the values does not have any negative effect
 	return nil
 }
 
-func resourceProjectGitDetailsExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	client := m.(*apiclient.LookerAPI30Reference)
-
-	// TODO Not sure if we should always set session to "dev" instead of "production" when checking if it exists? will dev always show all dev+prod projects?
-	err := updateSession(client, "dev")
+func resourceProjectGitDetailsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	err := setProjectGitDetails(d, m)
 	if err != nil {
-		return false, err
+		return diag.FromErr(err)
 	}
 
-	params := project.NewProjectParams()
-	params.ProjectID = d.Id()
-
-	_, err = client.Project.Project(params)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
+	if d.Get("test_connection").(bool) {
+		if diags := testProjectGitConnection(d.Id(), m.(*Client)); diags.HasError() {
+			// keep the previous state so the next plan applies the change again rather than accepting a broken remote
+			d.Partial(true)
+			return diags
 		}
-
-		return false, err
 	}
 
-	return true, nil
+	return resourceProjectGitDetailsRead(ctx, d, m)
 }
 
-func resourceProjectGitDetailsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceProjectGitDetailsRead(d, m); err != nil {
-		return nil, err
+// resourceProjectGitDetailsDelete sets the git fields back to blank values, detaching the project from its repository
+func resourceProjectGitDetailsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	err := client.withWorkspace(workspaceDev, func() error {
+		_, err := client.Project.updateProject(d.Id(), &projectGitDetails{GitPassword: new(string)})
+		return err
+	})
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
 	}
-	return []*schema.ResourceData{d}, nil
+
+	return nil
 }
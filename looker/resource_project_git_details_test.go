@@ -0,0 +1,81 @@
+package looker
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerProjectGitDetails(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// an SSH remote can't be reached before the project has a deploy key
+				Config:      testAccLookerProjectGitDetailsConfig(server, "git@github.com:example/analytics.git", false),
+				ExpectError: regexp.MustCompile(`(?s)Project analytics can't reach its git repository.*git_authentication: fail - Permission denied \(publickey\)`),
+			},
+			{
+				Config: testAccLookerProjectGitDetailsConfig(server, "git@github.com:example/analytics.git", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_project_git_details.test", "project_id", "analytics"),
+					resource.TestCheckResourceAttr("looker_project_git_details.test", "git_remote_url", "git@github.com:example/analytics.git"),
+					testAccCheckRemoteAttr(server, "projects", "looker_project_git_details.test", "git_remote_url", "git@github.com:example/analytics.git"),
+				),
+			},
+			{
+				Config: testAccLookerProjectGitDetailsConfig(server, "git@github.com:example/analytics-v2.git", true),
+				Check:  testAccCheckRemoteAttr(server, "projects", "looker_project_git_details.test", "git_remote_url", "git@github.com:example/analytics-v2.git"),
+			},
+			{
+				ResourceName:            "looker_project_git_details.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"git_password", "test_connection"},
+			},
+			{
+				// destroying the details detaches the project from its repository, keeping the project
+				Config: testAccLookerProjectGitDetailsConfig(server, "", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(server, "projects", "looker_project.test"),
+					testAccCheckRemoteAttr(server, "projects", "looker_project.test", "git_remote_url", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccLookerProjectGitDetailsConfig(server *lookertest.Server, remote string, deployKey bool) string {
+	config := server.ProviderConfig() + `
+resource "looker_project" "test" {
+  name = "analytics"
+}
+`
+	if deployKey {
+		config += `
+resource "looker_project_git_deploy_key" "test" {
+  project_id = looker_project.test.id
+}
+`
+	}
+	if remote != "" {
+		dependsOn := ""
+		if deployKey {
+			dependsOn = "depends_on = [looker_project_git_deploy_key.test]"
+		}
+		config += fmt.Sprintf(`
+resource "looker_project_git_details" "test" {
+  project_id     = looker_project.test.id
+  git_remote_url = %q
+
+  %s
+}
+`, remote, dependsOn)
+	}
+	return config
+}
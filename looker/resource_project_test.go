@@ -0,0 +1,100 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerProject(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		// Looker can't delete projects, destroying one renames it
+		CheckDestroy: testAccCheckDestroy(server, "projects", "looker_project"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerProjectConfig(server, "off"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(server, "projects", "looker_project.test"),
+					resource.TestCheckResourceAttr("looker_project.test", "id", "analytics"),
+					resource.TestCheckResourceAttr("looker_project.test", "pull_request_mode", "off"),
+				),
+			},
+			{
+				Config: testAccLookerProjectConfig(server, "required"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_project.test", "pull_request_mode", "required"),
+					testAccCheckRemoteAttr(server, "projects", "looker_project.test", "pull_request_mode", "required"),
+				),
+			},
+			{
+				ResourceName:            "looker_project.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"generate_git_deploy_key", "tombstone_on_destroy", "tombstone_name_prefix"},
+			},
+		},
+	})
+}
+
+func testAccLookerProjectConfig(server *lookertest.Server, pullRequestMode string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_project" "test" {
+  name                 = "analytics"
+  pull_request_mode    = %q
+  tombstone_on_destroy = true
+}
+`, pullRequestMode)
+}
+
+func TestAccLookerProjectUnmanagedPolicies(t *testing.T) {
+	server := testAccServer(t)
+
+	config := server.ProviderConfig() + `
+resource "looker_project" "test" {
+  name = "analytics"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_project.test", "pull_request_mode", "off"),
+					resource.TestCheckResourceAttr("looker_project.test", "allow_warnings", "true"),
+				),
+			},
+			{
+				// policies set in the UI are kept when the configuration leaves them out
+				PreConfig: func() {
+					server.Update("projects", "analytics", map[string]interface{}{
+						"pull_request_mode":   "required",
+						"validation_required": true,
+					})
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config: server.ProviderConfig() + `
+resource "looker_project" "test" {
+  name                     = "analytics"
+  git_release_mgmt_enabled = true
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_project.test", "pull_request_mode", "required"),
+					testAccCheckRemoteAttr(server, "projects", "looker_project.test", "pull_request_mode", "required"),
+					testAccCheckRemoteAttr(server, "projects", "looker_project.test", "validation_required", "true"),
+					testAccCheckRemoteAttr(server, "projects", "looker_project.test", "git_release_mgmt_enabled", "true"),
+				),
+			},
+		},
+	})
+}
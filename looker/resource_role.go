@@ -1,24 +1,23 @@
 package looker
 
 import (
-	"strings"
+	"context"
 
 	"github.com/billtrust/looker-go-sdk/client/role"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
 	"github.com/billtrust/looker-go-sdk/models"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceRole() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRoleCreate,
-		Read:   resourceRoleRead,
-		Update: resourceRoleUpdate,
-		Delete: resourceRoleDelete,
-		Exists: resourceRoleExists,
+		CreateContext: resourceRoleCreate,
+		ReadContext:   resourceRoleRead,
+		UpdateContext: resourceRoleUpdate,
+		DeleteContext: resourceRoleDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceRoleImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -38,17 +37,17 @@ func resourceRole() *schema.Resource {
 	}
 }
 
-func resourceRoleCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	permissionSetID, err := getIDFromString(d.Get("permission_set_id").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	modelSetID, err := getIDFromString(d.Get("model_set_id").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := role.NewCreateRoleParams()
@@ -59,20 +58,20 @@ func resourceRoleCreate(d *schema.ResourceData, m interface{}) error {
 
 	result, err := client.Role.CreateRole(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(getStringFromID(result.Payload.ID))
 
-	return resourceRoleRead(d, m)
+	return resourceRoleRead(ctx, d, m)
 }
 
-func resourceRoleRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := role.NewRoleParams()
@@ -80,36 +79,36 @@ func resourceRoleRead(d *schema.ResourceData, m interface{}) error {
 
 	result, err := client.Role.Role(params)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", result.Payload.Name)
-	d.Set("permission_set_id", result.Payload.PermissionSetID)
-	d.Set("model_set_id", result.Payload.ModelSetID)
+	d.Set("permission_set_id", getStringFromID(result.Payload.PermissionSetID))
+	d.Set("model_set_id", getStringFromID(result.Payload.ModelSetID))
 
 	return nil
 }
 
-func resourceRoleUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	permissionSetID, err := getIDFromString(d.Get("permission_set_id").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	modelSetID, err := getIDFromString(d.Get("model_set_id").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := role.NewUpdateRoleParams()
@@ -121,18 +120,18 @@ func resourceRoleUpdate(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Role.UpdateRole(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceRoleRead(d, m)
+	return resourceRoleRead(ctx, d, m)
 }
 
-func resourceRoleDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := role.NewDeleteRoleParams()
@@ -140,40 +139,8 @@ func resourceRoleDelete(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Role.DeleteRole(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
-
-func resourceRoleExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	client := m.(*apiclient.LookerAPI30Reference)
-
-	ID, err := getIDFromString(d.Id())
-	if err != nil {
-		return false, err
-	}
-
-	params := role.NewRoleParams()
-	params.RoleID = ID
-
-	_, err = client.Role.Role(params)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
-		}
-
-		return false, err
-	}
-
-	return true, nil
-}
-
-func resourceRoleImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceRoleRead(d, m); err != nil {
-		return nil, err
-	}
-	return []*schema.ResourceData{d}, nil
-}
@@ -1,23 +1,22 @@
 package looker
 
 import (
-	"strings"
+	"context"
 
 	"github.com/billtrust/looker-go-sdk/client/role"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceRoleGroups() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRoleGroupsCreate,
-		Read:   resourceRoleGroupsRead,
-		Update: resourceRoleGroupsUpdate,
-		Delete: resourceRoleGroupsDelete,
-		Exists: resourceRoleGroupsExists,
+		CreateContext: resourceRoleGroupsCreate,
+		ReadContext:   resourceRoleGroupsRead,
+		UpdateContext: resourceRoleGroupsUpdate,
+		DeleteContext: resourceRoleGroupsDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceRoleGroupsImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -34,19 +33,19 @@ func resourceRoleGroups() *schema.Resource {
 	}
 }
 
-func resourceRoleGroupsCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceRoleGroupsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Get("role_id").(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var groupIDs []int64
 	for _, sGroupID := range d.Get("group_ids").(*schema.Set).List() {
 		iGroupID, err := getIDFromString(sGroupID.(string))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		groupIDs = append(groupIDs, iGroupID)
@@ -58,20 +57,20 @@ func resourceRoleGroupsCreate(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Role.SetRoleGroups(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(d.Get("role_id").(string))
 
-	return resourceRoleGroupsRead(d, m)
+	return resourceRoleGroupsRead(ctx, d, m)
 }
 
-func resourceRoleGroupsRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceRoleGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := role.NewRoleGroupsParams()
@@ -79,32 +78,37 @@ func resourceRoleGroupsRead(d *schema.ResourceData, m interface{}) error {
 
 	result, err := client.Role.RoleGroups(params)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
-	d.Set("role_id", ID)
-	d.Set("group_ids", result.Payload)
+	groupIDs := []string{}
+	for _, group := range result.Payload {
+		groupIDs = append(groupIDs, getStringFromID(group.ID))
+	}
+
+	d.Set("role_id", d.Id())
+	d.Set("group_ids", groupIDs)
 
 	return nil
 }
 
-func resourceRoleGroupsUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceRoleGroupsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var groupIDs []int64
 	for _, sGroupID := range d.Get("group_ids").(*schema.Set).List() {
 		iGroupID, err := getIDFromString(sGroupID.(string))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		groupIDs = append(groupIDs, iGroupID)
@@ -116,18 +120,18 @@ func resourceRoleGroupsUpdate(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Role.SetRoleGroups(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceRoleGroupsRead(d, m)
+	return resourceRoleGroupsRead(ctx, d, m)
 }
 
-func resourceRoleGroupsDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceRoleGroupsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := role.NewSetRoleGroupsParams()
@@ -136,40 +140,8 @@ func resourceRoleGroupsDelete(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.Role.SetRoleGroups(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
-
-func resourceRoleGroupsExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	client := m.(*apiclient.LookerAPI30Reference)
-
-	ID, err := getIDFromString(d.Id())
-	if err != nil {
-		return false, err
-	}
-
-	params := role.NewRoleGroupsParams()
-	params.RoleID = ID
-
-	_, err = client.Role.RoleGroups(params)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
-		}
-
-		return false, err
-	}
-
-	return true, nil
-}
-
-func resourceRoleGroupsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceRoleGroupsRead(d, m); err != nil {
-		return nil, err
-	}
-	return []*schema.ResourceData{d}, nil
-}
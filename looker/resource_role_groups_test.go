@@ -0,0 +1,95 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLookerRoleGroups(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerRoleGroupsConfig(server, "looker_group.analysts.id, looker_group.engineers.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("looker_role_groups.test", "id", "looker_role.test", "id"),
+					resource.TestCheckResourceAttr("looker_role_groups.test", "group_ids.#", "2"),
+					testAccCheckRoleGroups(server, 2),
+				),
+			},
+			{
+				Config: testAccLookerRoleGroupsConfig(server, "looker_group.analysts.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_role_groups.test", "group_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("looker_role_groups.test", "group_ids.*", "looker_group.analysts", "id"),
+					testAccCheckRoleGroups(server, 1),
+				),
+			},
+			{
+				ResourceName:      "looker_role_groups.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// destroying the resource removes the role from every group
+				Config: testAccLookerRoleGroupsConfig(server, ""),
+				Check:  testAccCheckRoleGroups(server, 0),
+			},
+		},
+	})
+}
+
+func testAccLookerRoleGroupsConfig(server *lookertest.Server, groupIDs string) string {
+	config := server.ProviderConfig() + `
+data "looker_permission_set" "admin" {
+  name = "Admin"
+}
+
+data "looker_model_set" "all" {
+  name = "All"
+}
+
+resource "looker_role" "test" {
+  name              = "Analyst"
+  permission_set_id = data.looker_permission_set.admin.id
+  model_set_id      = data.looker_model_set.all.id
+}
+
+resource "looker_group" "analysts" {
+  name = "Analysts"
+}
+
+resource "looker_group" "engineers" {
+  name = "Engineers"
+}
+`
+	if groupIDs != "" {
+		config += fmt.Sprintf(`
+resource "looker_role_groups" "test" {
+  role_id   = looker_role.test.id
+  group_ids = [%s]
+}
+`, groupIDs)
+	}
+	return config
+}
+
+// testAccCheckRoleGroups checks the number of groups of the role as stored by the fake
+func testAccCheckRoleGroups(server *lookertest.Server, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		role, ok := server.Get("roles", s.RootModule().Resources["looker_role.test"].Primary.ID)
+		if !ok {
+			return fmt.Errorf("the role was not found")
+		}
+		if groups, _ := role["group_ids"].([]interface{}); len(groups) != count {
+			return fmt.Errorf("the role has groups %v, expected %d", groups, count)
+		}
+		return nil
+	}
+}
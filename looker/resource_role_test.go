@@ -0,0 +1,79 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerRole(t *testing.T) {
+	server := testAccServer(t)
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(server, "roles", "looker_role"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerRoleConfig(server, "Analyst"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(server, "roles", "looker_role.test"),
+					resource.TestCheckResourceAttrPair("looker_role.test", "permission_set_id", "looker_permission_set.test", "id"),
+					resource.TestCheckResourceAttrPair("looker_role.test", "model_set_id", "looker_model_set.test", "id"),
+					testAccCheckResourceAttrValue("looker_role.test", "id", &id),
+				),
+			},
+			{
+				Config: testAccLookerRoleConfig(server, "Senior Analyst"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_role.test", "name", "Senior Analyst"),
+					testAccCheckRemoteAttr(server, "roles", "looker_role.test", "name", "Senior Analyst"),
+				),
+			},
+			{
+				ResourceName:      "looker_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// a role deleted outside of terraform is removed from the state and planned again
+				PreConfig: func() {
+					server.Delete("roles", id)
+				},
+				Config:             testAccLookerRoleConfig(server, "Senior Analyst"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccLookerRoleConfig(server, "Senior Analyst"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(server, "roles", "looker_role.test"),
+					testAccCheckResourceAttrChanged("looker_role.test", "id", &id),
+				),
+			},
+		},
+	})
+}
+
+func testAccLookerRoleConfig(server *lookertest.Server, name string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_permission_set" "test" {
+  name        = "Analysts"
+  permissions = ["access_data", "see_looks"]
+}
+
+resource "looker_model_set" "test" {
+  name   = "Sales"
+  models = ["sales"]
+}
+
+resource "looker_role" "test" {
+  name              = %q
+  permission_set_id = looker_permission_set.test.id
+  model_set_id      = looker_model_set.test.id
+}
+`, name)
+}
@@ -1,23 +1,25 @@
 package looker
 
 import (
-	"strings"
+	"context"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
 	"github.com/billtrust/looker-go-sdk/client/user"
 	"github.com/billtrust/looker-go-sdk/models"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// resourceUser manages a user. The fields below the names are optional and computed: left out of the configuration,
+// they are only read, so they can be managed by looker_user_email, looker_user_roles, looker_group_users and the
+// like without diffs.
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserCreate,
-		Read:   resourceUserRead,
-		Update: resourceUserUpdate,
-		Delete: resourceUserDelete,
-		Exists: resourceUserExists,
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceUserImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -29,123 +31,288 @@ func resourceUser() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			// the email of the user's email credentials, which are created when missing
+			"email": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"is_disabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"locale": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"home_folder_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"models_dir_validated": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"ui_state": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// replace all the roles of the user
+			"role_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// the groups the user is directly a member of
+			"group_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// disables the user on destroy instead of deleting it, keeping its content and history
+			"deactivate_instead_of_delete": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
 
-func resourceUserCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+// getUserBody returns the fields of the user configured in d. On update, only the fields that changed are sent.
+func getUserBody(d *schema.ResourceData) *userBody {
+	changed := func(key string) bool {
+		return d.Id() == "" || d.HasChange(key)
+	}
+
+	body := &userBody{}
+	body.FirstName = d.Get("first_name").(string)
+	body.LastName = d.Get("last_name").(string)
+	if changed("is_disabled") {
+		isDisabled := d.Get("is_disabled").(bool)
+		body.IsDisabled = &isDisabled
+	}
+	if changed("locale") {
+		body.Locale = d.Get("locale").(string)
+	}
+	if changed("home_folder_id") {
+		body.HomeSpaceID = d.Get("home_folder_id").(string)
+	}
+	if changed("models_dir_validated") {
+		modelsDirValidated := d.Get("models_dir_validated").(bool)
+		body.ModelsDirValidated = &modelsDirValidated
+	}
+	if changed("ui_state") {
+		body.UIState = map[string]string{}
+		for k, v := range d.Get("ui_state").(map[string]interface{}) {
+			body.UIState[k] = v.(string)
+		}
+	}
+
+	return body
+}
+
+// setUserEmail sets the email of the user's email credentials, creating them if the user has none
+func setUserEmail(userID int64, email string, client *Client) error {
+	params := user.NewUserCredentialsEmailParams()
+	params.UserID = userID
+
+	_, err := client.User.UserCredentialsEmail(params)
+	if err != nil && !isNotFound(err) {
+		return err
+	}
 
-	params := user.NewCreateUserParams()
-	params.Body = &models.User{}
-	params.Body.FirstName = d.Get("first_name").(string)
-	params.Body.LastName = d.Get("last_name").(string)
+	if isNotFound(err) {
+		params := user.NewCreateUserCredentialsEmailParams()
+		params.UserID = userID
+		params.Body = &models.CredentialsEmail{Email: email}
 
-	user, err := client.User.CreateUser(params)
-	if err != nil {
+		_, err = client.User.CreateUserCredentialsEmail(params)
 		return err
 	}
 
-	d.SetId(getStringFromID(user.Payload.ID))
+	updateParams := user.NewUpdateUserCredentialsEmailParams()
+	updateParams.UserID = userID
+	updateParams.Body = &models.CredentialsEmail{Email: email}
 
-	return resourceUserRead(d, m)
+	_, err = client.User.UpdateUserCredentialsEmail(updateParams)
+	return err
 }
 
-func resourceUserRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+// setUserRoles replaces the roles of the user
+func setUserRoles(userID int64, roleIDs []string, client *Client) error {
+	params := user.NewSetUserRolesParams()
+	params.UserID = userID
+	params.Body = []int64{}
+	for _, roleID := range roleIDs {
+		ID, err := getIDFromString(roleID)
+		if err != nil {
+			return err
+		}
+		params.Body = append(params.Body, ID)
+	}
 
-	userID, err := getIDFromString(d.Id())
-	if err != nil {
-		return err
+	_, err := client.User.SetUserRoles(params)
+	return err
+}
+
+// setUserGroups adds the user to the groups it joined and removes it from the ones it left
+func setUserGroups(d *schema.ResourceData, client *Client) error {
+	o, n := d.GetChange("group_ids")
+	old, new := o.(*schema.Set), n.(*schema.Set)
+
+	for _, groupID := range new.Difference(old).List() {
+		ID, err := getIDFromString(groupID.(string))
+		if err != nil {
+			return err
+		}
+		if err := addGroupUser(ID, d.Id(), client); err != nil {
+			return err
+		}
 	}
 
-	params := user.NewUserParams()
-	params.UserID = userID
+	for _, groupID := range old.Difference(new).List() {
+		ID, err := getIDFromString(groupID.(string))
+		if err != nil {
+			return err
+		}
+		if err := removeGroupUser(ID, d.Id(), client); err != nil {
+			return err
+		}
+	}
 
-	user, err := client.User.User(params)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			d.SetId("")
-			return nil
+	return nil
+}
+
+// setUserMemberships sets the email, roles and groups of the user that are configured and changed
+func setUserMemberships(userID int64, d *schema.ResourceData, client *Client) error {
+	if email := d.Get("email").(string); email != "" && d.HasChange("email") {
+		if err := setUserEmail(userID, email, client); err != nil {
+			return err
 		}
-		return err
 	}
 
-	d.Set("first_name", user.Payload.FirstName)
-	d.Set("last_name", user.Payload.LastName)
+	if d.HasChange("role_ids") {
+		if err := setUserRoles(userID, getStringSet(d, "role_ids"), client); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("group_ids") {
+		if err := setUserGroups(d, client); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
-func resourceUserUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
-	userID, err := getIDFromString(d.Id())
+	user, err := client.User.createUser(getUserBody(d))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	params := user.NewUpdateUserParams()
-	params.UserID = userID
-	params.Body = &models.User{}
-	params.Body.FirstName = d.Get("first_name").(string)
-	params.Body.LastName = d.Get("last_name").(string)
+	d.SetId(getStringFromID(user.ID))
 
-	_, err = client.User.UpdateUser(params)
-	if err != nil {
-		return err
+	if err := setUserMemberships(user.ID, d, client); err != nil {
+		return diag.FromErr(err)
 	}
 
-	return resourceUserRead(d, m)
+	return resourceUserRead(ctx, d, m)
 }
 
-func resourceUserDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	userID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	params := user.NewDeleteUserParams()
-	params.UserID = userID
-
-	_, err = client.User.DeleteUser(params)
+	user, err := client.User.getUser(userID)
 	if err != nil {
-		return err
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
+	email := ""
+	if user.CredentialsEmail != nil {
+		email = user.CredentialsEmail.Email
+	}
+
+	d.Set("first_name", user.FirstName)
+	d.Set("last_name", user.LastName)
+	d.Set("email", email)
+	d.Set("is_disabled", user.IsDisabled)
+	d.Set("locale", user.Locale)
+	d.Set("home_folder_id", user.HomeSpaceID)
+	d.Set("models_dir_validated", user.ModelsDirValidated)
+	d.Set("ui_state", user.UIState)
+	d.Set("role_ids", getStringsFromIDs(user.RoleIds))
+	d.Set("group_ids", getStringsFromIDs(user.GroupIds))
+
 	return nil
 }
 
-func resourceUserExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	userID, err := getIDFromString(d.Id())
 	if err != nil {
-		return false, err
+		return diag.FromErr(err)
 	}
 
-	params := user.NewUserParams()
-	params.UserID = userID
+	_, err = client.User.updateUser(userID, getUserBody(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setUserMemberships(userID, d, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceUserRead(ctx, d, m)
+}
+
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
-	_, err = client.User.User(params)
+	userID, err := getIDFromString(d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
+		return diag.FromErr(err)
+	}
+
+	if d.Get("deactivate_instead_of_delete").(bool) {
+		isDisabled := true
+		_, err = client.User.updateUser(userID, &userBody{IsDisabled: &isDisabled})
+		if err != nil && !isNotFound(err) {
+			return diag.FromErr(err)
 		}
 
-		return false, err
+		return nil
 	}
 
-	return true, nil
-}
+	params := user.NewDeleteUserParams()
+	params.UserID = userID
 
-func resourceUserImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceUserRead(d, m); err != nil {
-		return nil, err
+	_, err = client.User.DeleteUser(params)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	return []*schema.ResourceData{d}, nil
+
+	return nil
 }
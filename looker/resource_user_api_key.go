@@ -1,23 +1,22 @@
 package looker
 
 import (
-	"fmt"
+	"context"
 	"strings"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/billtrust/looker-go-sdk/client/user"
-	"github.com/billtrust/looker-go-sdk/models"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceUserAPIKey() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserAPIKeyCreate,
-		Read:   resourceUserAPIKeyRead,
-		Delete: resourceUserAPIKeyDelete,
-		Exists: resourceUserAPIKeyExists,
+		CreateContext: resourceUserAPIKeyCreate,
+		ReadContext:   resourceUserAPIKeyRead,
+		DeleteContext: resourceUserAPIKeyDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceUserAPIKeyImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -30,41 +29,79 @@ func resourceUserAPIKey() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			// encrypts the secret into encrypted_client_secret instead of storing it in client_secret: a base64
+			// encoded public key, or "keybase:<username>"
+			"pgp_key": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			// only known when the key is created, so it's empty once imported
+			"client_secret": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"encrypted_client_secret": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// the fingerprint of the PGP key the secret is encrypted with
+			"key_fingerprint": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func resourceUserAPIKeyCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserAPIKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	sUserID := d.Get("user_id").(string)
 
 	iUserID, err := getIDFromString(sUserID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	params := user.NewCreateUserCredentialsApi3Params()
-	params.UserID = iUserID
-	params.Body = &models.CredentialsApi3{}
+	// fail before creating a key whose secret would be lost
+	var entity *openpgp.Entity
+	if pgpKey := d.Get("pgp_key").(string); pgpKey != "" {
+		entity, err = getPGPEntity(pgpKey)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
-	resp, err := client.User.CreateUserCredentialsApi3(params)
+	credentials, err := client.User.createCredentialsAPI3(iUserID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	id := sUserID + ":" + getStringFromID(resp.Payload.ID)
+	id := sUserID + ":" + getStringFromID(credentials.ID)
 	d.SetId(id)
 
-	return resourceUserAPIKeyRead(d, m)
+	if entity != nil {
+		encrypted, fingerprint, err := encryptWithPGP(entity, credentials.ClientSecret)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("encrypted_client_secret", encrypted)
+		d.Set("key_fingerprint", fingerprint)
+	} else {
+		d.Set("client_secret", credentials.ClientSecret)
+	}
+
+	return resourceUserAPIKeyRead(ctx, d, m)
 }
 
-func resourceUserAPIKeyRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserAPIKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	id := strings.Split(d.Id(), ":")
 	if len(id) != 2 {
-		return fmt.Errorf("ID Should be two strings separated by a colon (:)")
+		return diag.Errorf("ID Should be two strings separated by a colon (:)")
 	}
 
 	sUserID := id[0]
@@ -72,12 +109,12 @@ func resourceUserAPIKeyRead(d *schema.ResourceData, m interface{}) error {
 
 	iUserID, err := getIDFromString(sUserID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	iAPIID, err := getIDFromString(sAPIID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := user.NewUserCredentialsApi3Params()
@@ -86,11 +123,11 @@ func resourceUserAPIKeyRead(d *schema.ResourceData, m interface{}) error {
 
 	resp, err := client.User.UserCredentialsApi3(params)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("user_id", sUserID)
@@ -99,12 +136,12 @@ func resourceUserAPIKeyRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceUserAPIKeyDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserAPIKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	id := strings.Split(d.Id(), ":")
 	if len(id) != 2 {
-		return fmt.Errorf("ID Should be two strings separated by a colon (:)")
+		return diag.Errorf("ID Should be two strings separated by a colon (:)")
 	}
 
 	sUserID := id[0]
@@ -112,12 +149,12 @@ func resourceUserAPIKeyDelete(d *schema.ResourceData, m interface{}) error {
 
 	iUserID, err := getIDFromString(sUserID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	iAPIID, err := getIDFromString(sAPIID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := user.NewDeleteUserCredentialsApi3Params()
@@ -126,55 +163,8 @@ func resourceUserAPIKeyDelete(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.User.DeleteUserCredentialsApi3(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
-
-func resourceUserAPIKeyExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	client := m.(*apiclient.LookerAPI30Reference)
-
-	id := strings.Split(d.Id(), ":")
-	if len(id) != 2 {
-		return false, fmt.Errorf("ID Should be two strings separated by a colon (:)")
-	}
-
-	sUserID := id[0]
-	sAPIID := id[1]
-
-	iUserID, err := getIDFromString(sUserID)
-	if err != nil {
-		return false, err
-	}
-
-	iAPIID, err := getIDFromString(sAPIID)
-	if err != nil {
-		return false, err
-	}
-
-	params := user.NewUserCredentialsApi3Params()
-	params.UserID = iUserID
-	params.CredentialsApi3ID = iAPIID
-
-	_, err = client.User.UserCredentialsApi3(params)
-
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
-		}
-
-		return false, err
-	}
-
-	return true, nil
-}
-
-func resourceUserAPIKeyImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceUserAPIKeyRead(d, m); err != nil {
-		return nil, err
-	}
-	return []*schema.ResourceData{d}, nil
-}
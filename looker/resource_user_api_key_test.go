@@ -0,0 +1,99 @@
+package looker
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLookerUserAPIKey(t *testing.T) {
+	server := testAccServer(t)
+
+	entity := newTestPGPEntity(t)
+	buf := &bytes.Buffer{}
+	if err := entity.Serialize(buf); err != nil {
+		t.Fatal(err)
+	}
+	pgpKey := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckUserAPIKeyDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerUserAPIKeyConfig(server, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("looker_user_api_key.test", "client_id"),
+					resource.TestCheckResourceAttrSet("looker_user_api_key.test", "client_secret"),
+					resource.TestCheckNoResourceAttr("looker_user_api_key.test", "encrypted_client_secret"),
+					testAccCheckResourceAttrValue("looker_user_api_key.test", "id", &id),
+				),
+			},
+			{
+				ResourceName:      "looker_user_api_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the secret is only returned when the key is created
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+			{
+				// a pgp_key replaces the key, as the secret of the current one can't be read again
+				Config: testAccLookerUserAPIKeyConfig(server, pgpKey),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceAttrChanged("looker_user_api_key.test", "id", &id),
+					resource.TestCheckNoResourceAttr("looker_user_api_key.test", "client_secret"),
+					resource.TestCheckResourceAttrSet("looker_user_api_key.test", "encrypted_client_secret"),
+					resource.TestCheckResourceAttr("looker_user_api_key.test", "key_fingerprint", hex.EncodeToString(entity.PrimaryKey.Fingerprint)),
+				),
+			},
+		},
+	})
+}
+
+func testAccLookerUserAPIKeyConfig(server *lookertest.Server, pgpKey string) string {
+	config := server.ProviderConfig() + `
+resource "looker_user" "test" {
+  first_name = "Jane"
+  last_name  = "Doe"
+}
+`
+	if pgpKey == "" {
+		return config + `
+resource "looker_user_api_key" "test" {
+  user_id = looker_user.test.id
+}
+`
+	}
+	return config + fmt.Sprintf(`
+resource "looker_user_api_key" "test" {
+  user_id = looker_user.test.id
+  pgp_key = %q
+}
+`, pgpKey)
+}
+
+// testAccCheckUserAPIKeyDestroy checks the keys in the state, with IDs of the form <user_id>:<credentials_api3_id>,
+// were deleted from the fake
+func testAccCheckUserAPIKeyDestroy(server *lookertest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "looker_user_api_key" {
+				continue
+			}
+			_, credentialsID, _ := strings.Cut(rs.Primary.ID, ":")
+			if _, ok := server.Get("credentials_api3", credentialsID); ok {
+				return fmt.Errorf("looker_user_api_key %s still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
@@ -1,25 +1,42 @@
 package looker
 
 import (
-	"strings"
+	"context"
+	"fmt"
 
 	"github.com/billtrust/looker-go-sdk/client/user_attribute"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
 	"github.com/billtrust/looker-go-sdk/models"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+var userAttributeTypes = []string{
+	"string",
+	"number",
+	"datetime",
+	"relative_url",
+	"yesno",
+	"zipcode",
+	"advanced_filter_string",
+	"advanced_filter_number",
+	"advanced_filter_datetime",
+}
+
 func resourceUserAttribute() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserAttributeCreate,
-		Read:   resourceUserAttributeRead,
-		Update: resourceUserAttributeUpdate,
-		Delete: resourceUserAttributeDelete,
-		Exists: resourceUserAttributeExists,
+		CreateContext: resourceUserAttributeCreate,
+		ReadContext:   resourceUserAttributeRead,
+		UpdateContext: resourceUserAttributeUpdate,
+		DeleteContext: resourceUserAttributeDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceUserAttributeImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			resourceUserAttributeForceNewOnShow,
+			resourceUserAttributeValidateAccess,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -27,43 +44,101 @@ func resourceUserAttribute() *schema.Resource {
 				Required: true,
 			},
 			"type": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				// TODO: hard code to only allow "advanced_filter_string" for now
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateOneOf("user attribute type", userAttributeTypes),
 			},
 			"label": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			// sensitive, as the default of a hidden attribute is as secret as its other values
+			"default_value": &schema.Schema{
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"value_is_hidden": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"hidden_value_domain_whitelist": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"user_can_view": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"user_can_edit": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func resourceUserAttributeCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+// resourceUserAttributeForceNewOnShow recreates an attribute whose values are hidden when value_is_hidden is set to
+// false, as Looker doesn't allow showing them again. Leaving value_is_hidden out keeps an attribute hidden in the UI.
+func resourceUserAttributeForceNewOnShow(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		return nil
+	}
+
+	hidden := config.GetAttr("value_is_hidden")
+	old, _ := d.GetChange("value_is_hidden")
+	if !old.(bool) || hidden.IsNull() || !hidden.IsKnown() || hidden.True() {
+		return nil
+	}
+
+	return d.ForceNew("value_is_hidden")
+}
+
+func resourceUserAttributeValidateAccess(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("user_can_edit").(bool) && !d.Get("user_can_view").(bool) {
+		return fmt.Errorf("user_can_edit requires user_can_view")
+	}
+	if d.Get("value_is_hidden").(bool) && d.Get("hidden_value_domain_whitelist").(string) == "" {
+		return fmt.Errorf("hidden_value_domain_whitelist is required when value_is_hidden is true")
+	}
+	return nil
+}
+
+func resourceUserAttributeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	params := user_attribute.NewCreateUserAttributeParams()
 	params.Body = &models.UserAttribute{}
 	params.Body.Name = d.Get("name").(string)
 	params.Body.Type = d.Get("type").(string)
 	params.Body.Label = d.Get("label").(string)
+	params.Body.DefaultValue = d.Get("default_value").(string)
+	params.Body.ValueIsHidden = d.Get("value_is_hidden").(bool)
+	params.Body.HiddenValueDomainWhitelist = d.Get("hidden_value_domain_whitelist").(string)
+	params.Body.UserCanView = d.Get("user_can_view").(bool)
+	params.Body.UserCanEdit = d.Get("user_can_edit").(bool)
 
 	result, err := client.UserAttribute.CreateUserAttribute(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(getStringFromID(result.Payload.ID))
 
-	return resourceUserAttributeRead(d, m)
+	return resourceUserAttributeRead(ctx, d, m)
 }
 
-func resourceUserAttributeRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserAttributeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := user_attribute.NewUserAttributeParams()
@@ -71,26 +146,35 @@ func resourceUserAttributeRead(d *schema.ResourceData, m interface{}) error {
 
 	result, err := client.UserAttribute.UserAttribute(params)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("name", result.Payload.Name)
 	d.Set("type", result.Payload.Type)
 	d.Set("label", result.Payload.Label)
+	d.Set("value_is_hidden", result.Payload.ValueIsHidden)
+	d.Set("hidden_value_domain_whitelist", result.Payload.HiddenValueDomainWhitelist)
+	d.Set("user_can_view", result.Payload.UserCanView)
+	d.Set("user_can_edit", result.Payload.UserCanEdit)
+
+	// the default of a hidden attribute isn't returned
+	if !result.Payload.ValueIsHidden {
+		d.Set("default_value", result.Payload.DefaultValue)
+	}
 
 	return nil
 }
 
-func resourceUserAttributeUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserAttributeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := user_attribute.NewUpdateUserAttributeParams()
@@ -99,21 +183,26 @@ func resourceUserAttributeUpdate(d *schema.ResourceData, m interface{}) error {
 	params.Body.Name = d.Get("name").(string)
 	params.Body.Type = d.Get("type").(string)
 	params.Body.Label = d.Get("label").(string)
+	params.Body.DefaultValue = d.Get("default_value").(string)
+	params.Body.ValueIsHidden = d.Get("value_is_hidden").(bool)
+	params.Body.HiddenValueDomainWhitelist = d.Get("hidden_value_domain_whitelist").(string)
+	params.Body.UserCanView = d.Get("user_can_view").(bool)
+	params.Body.UserCanEdit = d.Get("user_can_edit").(bool)
 
 	_, err = client.UserAttribute.UpdateUserAttribute(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceUserAttributeRead(d, m)
+	return resourceUserAttributeRead(ctx, d, m)
 }
 
-func resourceUserAttributeDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserAttributeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	ID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := user_attribute.NewDeleteUserAttributeParams()
@@ -121,40 +210,8 @@ func resourceUserAttributeDelete(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.UserAttribute.DeleteUserAttribute(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
-
-func resourceUserAttributeExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	client := m.(*apiclient.LookerAPI30Reference)
-
-	ID, err := getIDFromString(d.Id())
-	if err != nil {
-		return false, err
-	}
-
-	params := user_attribute.NewUserAttributeParams()
-	params.UserAttributeID = ID
-
-	_, err = client.UserAttribute.UserAttribute(params)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
-		}
-
-		return false, err
-	}
-
-	return true, nil
-}
-
-func resourceUserAttributeImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceUserAttributeRead(d, m); err != nil {
-		return nil, err
-	}
-	return []*schema.ResourceData{d}, nil
-}
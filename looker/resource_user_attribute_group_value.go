@@ -0,0 +1,161 @@
+package looker
+
+import (
+	"context"
+	"sort"
+
+	"github.com/billtrust/looker-go-sdk/client/user_attribute"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceUserAttributeGroupValue() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserAttributeGroupValueCreate,
+		ReadContext:   resourceUserAttributeGroupValueRead,
+		UpdateContext: resourceUserAttributeGroupValueUpdate,
+		DeleteContext: resourceUserAttributeGroupValueDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_attribute_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// ordered by rank: when a user is in several of the groups, the value of the first one applies
+			"group_value": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": &schema.Schema{
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func setUserAttributeGroupValues(d *schema.ResourceData, m interface{}, groupValues []interface{}) error {
+	client := m.(*Client)
+
+	ID, err := getIDFromString(d.Get("user_attribute_id").(string))
+	if err != nil {
+		return err
+	}
+
+	params := user_attribute.NewSetUserAttributeGroupValuesParams()
+	params.UserAttributeID = ID
+	params.Body = []*models.UserAttributeGroupValue{}
+
+	for i, item := range groupValues {
+		groupValue := item.(map[string]interface{})
+
+		groupID, err := getIDFromString(groupValue["group_id"].(string))
+		if err != nil {
+			return err
+		}
+
+		params.Body = append(params.Body, &models.UserAttributeGroupValue{
+			GroupID:         groupID,
+			UserAttributeID: ID,
+			Rank:            int64(i + 1),
+			Value:           groupValue["value"].(string),
+		})
+	}
+
+	_, err = client.UserAttribute.SetUserAttributeGroupValues(params)
+	return err
+}
+
+func resourceUserAttributeGroupValueCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := setUserAttributeGroupValues(d, m, d.Get("group_value").([]interface{})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("user_attribute_id").(string))
+
+	return resourceUserAttributeGroupValueRead(ctx, d, m)
+}
+
+func resourceUserAttributeGroupValueRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	ID, err := getIDFromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := user_attribute.NewAllUserAttributeGroupValuesParams()
+	params.UserAttributeID = ID
+
+	result, err := client.UserAttribute.AllUserAttributeGroupValues(params)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// hidden values aren't returned, so they are kept as configured
+	configured := map[string]string{}
+	for _, item := range d.Get("group_value").([]interface{}) {
+		groupValue := item.(map[string]interface{})
+		configured[groupValue["group_id"].(string)] = groupValue["value"].(string)
+	}
+
+	sort.Slice(result.Payload, func(i, j int) bool {
+		return result.Payload[i].Rank < result.Payload[j].Rank
+	})
+
+	groupValues := []map[string]interface{}{}
+	for _, groupValue := range result.Payload {
+		groupID := getStringFromID(groupValue.GroupID)
+
+		value := groupValue.Value
+		if groupValue.ValueIsHidden != nil && *groupValue.ValueIsHidden {
+			value = configured[groupID]
+		}
+
+		groupValues = append(groupValues, map[string]interface{}{
+			"group_id": groupID,
+			"value":    value,
+		})
+	}
+
+	d.Set("user_attribute_id", d.Id())
+	d.Set("group_value", groupValues)
+
+	return nil
+}
+
+func resourceUserAttributeGroupValueUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := setUserAttributeGroupValues(d, m, d.Get("group_value").([]interface{})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceUserAttributeGroupValueRead(ctx, d, m)
+}
+
+func resourceUserAttributeGroupValueDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	err := setUserAttributeGroupValues(d, m, []interface{}{})
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
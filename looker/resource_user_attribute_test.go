@@ -0,0 +1,85 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerUserAttribute(t *testing.T) {
+	server := testAccServer(t)
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(server, "user_attributes", "looker_user_attribute"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerUserAttributeConfig(server, "Department", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(server, "user_attributes", "looker_user_attribute.test"),
+					resource.TestCheckResourceAttr("looker_user_attribute.test", "label", "Department"),
+					resource.TestCheckResourceAttr("looker_user_attribute.test", "value_is_hidden", "false"),
+					testAccCheckResourceAttrValue("looker_user_attribute.test", "id", &id),
+				),
+			},
+			{
+				Config: testAccLookerUserAttributeConfig(server, "Team", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_user_attribute.test", "label", "Team"),
+					testAccCheckRemoteAttr(server, "user_attributes", "looker_user_attribute.test", "label", "Team"),
+				),
+			},
+			{
+				// flags set in the UI are kept when the configuration leaves them out
+				PreConfig: func() {
+					server.Update("user_attributes", id, map[string]interface{}{
+						"value_is_hidden":               true,
+						"hidden_value_domain_whitelist": "https://*.example.com/*",
+						"user_can_view":                 true,
+					})
+				},
+				Config:   testAccLookerUserAttributeConfig(server, "Team", ""),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccLookerUserAttributeConfig(server, "Team", "user_can_edit = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("looker_user_attribute.test", "id", &id),
+					testAccCheckRemoteAttr(server, "user_attributes", "looker_user_attribute.test", "value_is_hidden", "true"),
+					testAccCheckRemoteAttr(server, "user_attributes", "looker_user_attribute.test", "user_can_view", "true"),
+					testAccCheckRemoteAttr(server, "user_attributes", "looker_user_attribute.test", "user_can_edit", "true"),
+				),
+			},
+			{
+				// hidden values can't be shown again, so only an explicit false recreates the attribute
+				Config: testAccLookerUserAttributeConfig(server, "Team", "value_is_hidden = false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_user_attribute.test", "value_is_hidden", "false"),
+					testAccCheckResourceAttrChanged("looker_user_attribute.test", "id", &id),
+				),
+			},
+			{
+				ResourceName:            "looker_user_attribute.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"default_value"},
+			},
+		},
+	})
+}
+
+func testAccLookerUserAttributeConfig(server *lookertest.Server, label string, extra string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_user_attribute" "test" {
+  name          = "department"
+  type          = "string"
+  label         = %q
+  default_value = "none"
+  %s
+}
+`, label, extra)
+}
@@ -0,0 +1,166 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/billtrust/looker-go-sdk/client/user"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceUserAttributeUserValue() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserAttributeUserValueCreate,
+		ReadContext:   resourceUserAttributeUserValueRead,
+		UpdateContext: resourceUserAttributeUserValueUpdate,
+		DeleteContext: resourceUserAttributeUserValueDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_attribute_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"value": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func getUserAttributeUserValueID(d *schema.ResourceData) (int64, int64, error) {
+	id := strings.Split(d.Id(), ":")
+	if len(id) != 2 {
+		return 0, 0, fmt.Errorf("Invalid id %q, expected <user_id>:<user_attribute_id>", d.Id())
+	}
+
+	userID, err := getIDFromString(id[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	userAttributeID, err := getIDFromString(id[1])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return userID, userAttributeID, nil
+}
+
+func setUserAttributeUserValue(d *schema.ResourceData, m interface{}) error {
+	client := m.(*Client)
+
+	userID, err := getIDFromString(d.Get("user_id").(string))
+	if err != nil {
+		return err
+	}
+
+	userAttributeID, err := getIDFromString(d.Get("user_attribute_id").(string))
+	if err != nil {
+		return err
+	}
+
+	params := user.NewSetUserAttributeUserValueParams()
+	params.UserID = userID
+	params.UserAttributeID = userAttributeID
+	params.Body = &models.UserAttributeWithValue{}
+	params.Body.Value = d.Get("value").(string)
+
+	_, err = client.User.SetUserAttributeUserValue(params)
+	return err
+}
+
+func resourceUserAttributeUserValueCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := setUserAttributeUserValue(d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("user_id").(string) + ":" + d.Get("user_attribute_id").(string))
+
+	return resourceUserAttributeUserValueRead(ctx, d, m)
+}
+
+func resourceUserAttributeUserValueRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	userID, userAttributeID, err := getUserAttributeUserValueID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := user.NewUserAttributeUserValuesParams()
+	params.UserID = userID
+	params.UserAttributeIds = []int64{userAttributeID}
+
+	result, err := client.User.UserAttributeUserValues(params)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// values coming from a group or the attribute's default mean the user's own value is gone
+	var value *models.UserAttributeWithValue
+	for _, item := range result.Payload {
+		if item.UserAttributeID == userAttributeID && item.Source == "user" {
+			value = item
+		}
+	}
+	if value == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("user_id", getStringFromID(userID))
+	d.Set("user_attribute_id", getStringFromID(userAttributeID))
+
+	// hidden values aren't returned, so they are kept as configured
+	if value.ValueIsHidden == nil || !*value.ValueIsHidden {
+		d.Set("value", value.Value)
+	}
+
+	return nil
+}
+
+func resourceUserAttributeUserValueUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := setUserAttributeUserValue(d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceUserAttributeUserValueRead(ctx, d, m)
+}
+
+func resourceUserAttributeUserValueDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	userID, userAttributeID, err := getUserAttributeUserValueID(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	params := user.NewDeleteUserAttributeUserValueParams()
+	params.UserID = userID
+	params.UserAttributeID = userAttributeID
+
+	_, err = client.User.DeleteUserAttributeUserValue(params)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,327 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/billtrust/looker-go-sdk/client/user"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// userCredentialType describes credentials Looker links to a user when they first log in through an identity
+// provider. The API can read and delete them, but not create them.
+type userCredentialType struct {
+	// the name of the credentials, e.g. "saml", as in credentials_saml
+	name string
+	// how users log in with them, for error messages
+	description string
+	// the fields of the linked identity, besides the ones all credentials have
+	fields []string
+	// get returns the fields of the user's credentials, or nil if the user has none
+	get func(userID int64, client *Client) (map[string]interface{}, error)
+	// delete unlinks the credentials from the user, given the fields get returned
+	delete func(userID int64, fields map[string]interface{}, client *Client) error
+}
+
+// the credential types, each managed by a looker_user_credentials_<name> resource
+
+var userCredentialsSAML = &userCredentialType{
+	name:        "saml",
+	description: "through SAML",
+	fields:      []string{"saml_user_id", "email"},
+	get: func(userID int64, client *Client) (map[string]interface{}, error) {
+		params := user.NewUserCredentialsSamlParams()
+		params.UserID = userID
+
+		result, err := client.User.UserCredentialsSaml(params)
+		if err != nil {
+			return nil, err
+		}
+
+		c := result.Payload
+		return map[string]interface{}{
+			"saml_user_id": c.SamlUserID,
+			"email":        c.Email,
+			"is_disabled":  c.IsDisabled,
+			"created_at":   c.CreatedAt,
+			"logged_in_at": c.LoggedInAt,
+		}, nil
+	},
+	delete: func(userID int64, fields map[string]interface{}, client *Client) error {
+		params := user.NewDeleteUserCredentialsSamlParams()
+		params.UserID = userID
+
+		_, err := client.User.DeleteUserCredentialsSaml(params)
+		return err
+	},
+}
+
+var userCredentialsOIDC = &userCredentialType{
+	name:        "oidc",
+	description: "through OpenID Connect",
+	fields:      []string{"oidc_user_id", "email"},
+	get: func(userID int64, client *Client) (map[string]interface{}, error) {
+		params := user.NewUserCredentialsOidcParams()
+		params.UserID = userID
+
+		result, err := client.User.UserCredentialsOidc(params)
+		if err != nil {
+			return nil, err
+		}
+
+		c := result.Payload
+		return map[string]interface{}{
+			"oidc_user_id": c.OidcUserID,
+			"email":        c.Email,
+			"is_disabled":  c.IsDisabled,
+			"created_at":   c.CreatedAt,
+			"logged_in_at": c.LoggedInAt,
+		}, nil
+	},
+	delete: func(userID int64, fields map[string]interface{}, client *Client) error {
+		params := user.NewDeleteUserCredentialsOidcParams()
+		params.UserID = userID
+
+		_, err := client.User.DeleteUserCredentialsOidc(params)
+		return err
+	},
+}
+
+var userCredentialsLDAP = &userCredentialType{
+	name:        "ldap",
+	description: "through LDAP",
+	fields:      []string{"ldap_id", "ldap_dn", "email"},
+	get: func(userID int64, client *Client) (map[string]interface{}, error) {
+		params := user.NewUserCredentialsLdapParams()
+		params.UserID = userID
+
+		result, err := client.User.UserCredentialsLdap(params)
+		if err != nil {
+			return nil, err
+		}
+
+		c := result.Payload
+		return map[string]interface{}{
+			"ldap_id":      c.LdapID,
+			"ldap_dn":      c.LdapDn,
+			"email":        c.Email,
+			"is_disabled":  c.IsDisabled,
+			"created_at":   c.CreatedAt,
+			"logged_in_at": c.LoggedInAt,
+		}, nil
+	},
+	delete: func(userID int64, fields map[string]interface{}, client *Client) error {
+		params := user.NewDeleteUserCredentialsLdapParams()
+		params.UserID = userID
+
+		_, err := client.User.DeleteUserCredentialsLdap(params)
+		return err
+	},
+}
+
+var userCredentialsGoogle = &userCredentialType{
+	name:        "google",
+	description: "with Google",
+	fields:      []string{"google_user_id", "domain", "email"},
+	get: func(userID int64, client *Client) (map[string]interface{}, error) {
+		params := user.NewUserCredentialsGoogleParams()
+		params.UserID = userID
+
+		result, err := client.User.UserCredentialsGoogle(params)
+		if err != nil {
+			return nil, err
+		}
+
+		c := result.Payload
+		return map[string]interface{}{
+			"google_user_id": c.GoogleUserID,
+			"domain":         c.Domain,
+			"email":          c.Email,
+			"is_disabled":    c.IsDisabled,
+			"created_at":     c.CreatedAt,
+			"logged_in_at":   c.LoggedInAt,
+		}, nil
+	},
+	delete: func(userID int64, fields map[string]interface{}, client *Client) error {
+		params := user.NewDeleteUserCredentialsGoogleParams()
+		params.UserID = userID
+
+		_, err := client.User.DeleteUserCredentialsGoogle(params)
+		return err
+	},
+}
+
+// embed users have a single embed credential, created by their first signed embed URL
+var userCredentialsEmbed = &userCredentialType{
+	name:        "embed",
+	description: "through a signed embed URL",
+	fields:      []string{"credentials_embed_id", "external_user_id", "external_group_id"},
+	get: func(userID int64, client *Client) (map[string]interface{}, error) {
+		params := user.NewAllUserCredentialsEmbedsParams()
+		params.UserID = userID
+
+		result, err := client.User.AllUserCredentialsEmbeds(params)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Payload) == 0 {
+			return nil, nil
+		}
+
+		c := result.Payload[0]
+		return map[string]interface{}{
+			"credentials_embed_id": getStringFromID(c.ID),
+			"external_user_id":     c.ExternalUserID,
+			"external_group_id":    c.ExternalGroupID,
+			"is_disabled":          c.IsDisabled,
+			"created_at":           c.CreatedAt,
+			"logged_in_at":         c.LoggedInAt,
+		}, nil
+	},
+	delete: func(userID int64, fields map[string]interface{}, client *Client) error {
+		credentialsEmbedID, err := getIDFromString(fields["credentials_embed_id"].(string))
+		if err != nil {
+			return err
+		}
+
+		params := user.NewDeleteUserCredentialsEmbedParams()
+		params.UserID = userID
+		params.CredentialsEmbedID = credentialsEmbedID
+
+		_, err = client.User.DeleteUserCredentialsEmbed(params)
+		return err
+	},
+}
+
+// resourceUserCredentials manages the credentials of a type linked to a user. They can't be created, so creating the
+// resource adopts the existing credentials, and destroying it deletes them, e.g. to unlink a stale identity provider
+// account so the next login links the right one. The ID is the user ID.
+func resourceUserCredentials(credentialType *userCredentialType) *schema.Resource {
+	s := map[string]*schema.Schema{
+		"user_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"is_disabled": &schema.Schema{
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"created_at": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"logged_in_at": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	for _, field := range credentialType.fields {
+		s[field] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+	}
+
+	return &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceUserCredentialsCreate(ctx, d, m, credentialType)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceUserCredentialsRead(ctx, d, m, credentialType)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceUserCredentialsDelete(ctx, d, m, credentialType)
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: s,
+	}
+}
+
+// getUserCredentials returns the fields of the user's credentials, or nil if the user has none
+func getUserCredentials(userID int64, client *Client, credentialType *userCredentialType) (map[string]interface{}, error) {
+	fields, err := credentialType.get(userID, client)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func resourceUserCredentialsCreate(ctx context.Context, d *schema.ResourceData, m interface{}, credentialType *userCredentialType) diag.Diagnostics {
+	client := m.(*Client)
+
+	sUserID := d.Get("user_id").(string)
+
+	userID, err := getIDFromString(sUserID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	fields, err := getUserCredentials(userID, client, credentialType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if fields == nil {
+		return diag.Errorf("User %s has no %s credentials. Looker's API can't create them: they're linked when the user first logs in %s.", sUserID, credentialType.name, credentialType.description)
+	}
+
+	d.SetId(sUserID)
+
+	return resourceUserCredentialsRead(ctx, d, m, credentialType)
+}
+
+func resourceUserCredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}, credentialType *userCredentialType) diag.Diagnostics {
+	client := m.(*Client)
+
+	userID, err := getIDFromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	fields, err := getUserCredentials(userID, client, credentialType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if fields == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("user_id", d.Id())
+	for key, value := range fields {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(fmt.Errorf("Can't set %s: %s", key, err))
+		}
+	}
+
+	return nil
+}
+
+func resourceUserCredentialsDelete(ctx context.Context, d *schema.ResourceData, m interface{}, credentialType *userCredentialType) diag.Diagnostics {
+	client := m.(*Client)
+
+	userID, err := getIDFromString(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	fields := map[string]interface{}{}
+	for _, field := range credentialType.fields {
+		fields[field] = d.Get(field)
+	}
+
+	err = credentialType.delete(userID, fields, client)
+	if err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
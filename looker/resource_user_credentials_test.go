@@ -0,0 +1,145 @@
+package looker
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLookerUserCredentialsSaml(t *testing.T) {
+	server := testAccServer(t)
+
+	var userID string
+	link := func() {
+		server.LinkCredentials(userID, "saml", map[string]interface{}{
+			"saml_user_id": "jane@idp.example.com",
+			"email":        "jane@example.com",
+			"is_disabled":  false,
+			"created_at":   "2026-01-05T10:00:00.000+00:00",
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerUserCredentialsConfig(server, ""),
+				Check:  testAccCheckResourceAttrValue("looker_user.test", "id", &userID),
+			},
+			{
+				// the API can't create credentials, so there must be some to adopt
+				Config:      testAccLookerUserCredentialsConfig(server, "saml"),
+				ExpectError: regexp.MustCompile("has no saml credentials"),
+			},
+			{
+				PreConfig: link,
+				Config:    testAccLookerUserCredentialsConfig(server, "saml"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("looker_user_credentials_saml.test", "id", "looker_user.test", "id"),
+					resource.TestCheckResourceAttr("looker_user_credentials_saml.test", "saml_user_id", "jane@idp.example.com"),
+					resource.TestCheckResourceAttr("looker_user_credentials_saml.test", "email", "jane@example.com"),
+					resource.TestCheckResourceAttr("looker_user_credentials_saml.test", "created_at", "2026-01-05T10:00:00.000+00:00"),
+				),
+			},
+			{
+				ResourceName:      "looker_user_credentials_saml.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// credentials deleted outside of terraform are removed from the state, so they're adopted again
+				PreConfig: func() {
+					server.Delete("credentials_saml", userID)
+				},
+				Config:             testAccLookerUserCredentialsConfig(server, "saml"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: link,
+				Config:    testAccLookerUserCredentialsConfig(server, "saml"),
+				Check:     resource.TestCheckResourceAttr("looker_user_credentials_saml.test", "saml_user_id", "jane@idp.example.com"),
+			},
+			{
+				// destroying the resource unlinks the credentials from the user
+				Config: testAccLookerUserCredentialsConfig(server, ""),
+				Check: resource.ComposeTestCheckFunc(
+					func(*terraform.State) error {
+						if _, ok := server.Get("credentials_saml", userID); ok {
+							return fmt.Errorf("the saml credentials of user %s weren't deleted", userID)
+						}
+						return nil
+					},
+					testAccCheckExists(server, "users", "looker_user.test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLookerUserCredentialsEmbed(t *testing.T) {
+	server := testAccServer(t)
+
+	var userID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerUserCredentialsConfig(server, ""),
+				Check:  testAccCheckResourceAttrValue("looker_user.test", "id", &userID),
+			},
+			{
+				PreConfig: func() {
+					server.LinkCredentials(userID, "embed", map[string]interface{}{
+						"external_user_id":  "customer-42",
+						"external_group_id": "customers",
+					})
+				},
+				Config: testAccLookerUserCredentialsConfig(server, "embed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_user_credentials_embed.test", "external_user_id", "customer-42"),
+					resource.TestCheckResourceAttr("looker_user_credentials_embed.test", "external_group_id", "customers"),
+					resource.TestCheckResourceAttrSet("looker_user_credentials_embed.test", "credentials_embed_id"),
+				),
+			},
+			{
+				ResourceName:      "looker_user_credentials_embed.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccLookerUserCredentialsConfig(server, ""),
+				Check: func(*terraform.State) error {
+					user, _ := server.Get("users", userID)
+					if embeds, _ := user["credentials_embed"].([]interface{}); len(embeds) != 0 {
+						return fmt.Errorf("the embed credentials of user %s weren't deleted: %v", userID, embeds)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// testAccLookerUserCredentialsConfig returns a user, with its credentials of a type unless credentialType is empty
+func testAccLookerUserCredentialsConfig(server *lookertest.Server, credentialType string) string {
+	config := server.ProviderConfig() + `
+resource "looker_user" "test" {
+  first_name = "Jane"
+  last_name  = "Doe"
+}
+`
+	if credentialType != "" {
+		config += fmt.Sprintf(`
+resource "looker_user_credentials_%s" "test" {
+  user_id = looker_user.test.id
+}
+`, credentialType)
+	}
+	return config
+}
@@ -1,25 +1,26 @@
 package looker
 
 import (
+	"context"
+	"fmt"
 	"log"
-	"strings"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
 	"github.com/billtrust/looker-go-sdk/client/user"
 	"github.com/billtrust/looker-go-sdk/models"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceUserEmail() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserEmailCreate,
-		Read:   resourceUserEmailRead,
-		Update: resourceUserEmailUpdate,
-		Delete: resourceUserEmailDelete,
-		Exists: resourceUserEmailExists,
+		CreateContext: resourceUserEmailCreate,
+		ReadContext:   resourceUserEmailRead,
+		UpdateContext: resourceUserEmailUpdate,
+		DeleteContext: resourceUserEmailDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceUserEmailImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceUserEmailCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"user_id": &schema.Schema{
@@ -30,12 +31,25 @@ func resourceUserEmail() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			// emails the user a link to set their password when the credentials are created, or when set later on.
+			// Requires API 4.0.
+			"send_setup_email": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// the link to set the password, from the setup email when sent, otherwise created without emailing it
+			"password_reset_url": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
 		},
 	}
 }
 
-func resourceUserEmailCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserEmailCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	params := user.NewCreateUserCredentialsEmailParams()
 
@@ -43,7 +57,7 @@ func resourceUserEmailCreate(d *schema.ResourceData, m interface{}) error {
 
 	iUserID, err := getIDFromString(sUserID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params.UserID = iUserID
@@ -55,20 +69,24 @@ func resourceUserEmailCreate(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.User.CreateUserCredentialsEmail(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(sUserID)
 
-	return resourceUserEmailRead(d, m)
+	if err := setPasswordResetURL(iUserID, d, client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceUserEmailRead(ctx, d, m)
 }
 
-func resourceUserEmailRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserEmailRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	userID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := user.NewUserCredentialsEmailParams()
@@ -76,24 +94,25 @@ func resourceUserEmailRead(d *schema.ResourceData, m interface{}) error {
 
 	user, err := client.User.UserCredentialsEmail(params)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
+	d.Set("user_id", d.Id())
 	d.Set("email", user.Payload.Email)
 
 	return nil
 }
 
-func resourceUserEmailUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserEmailUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	userID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := user.NewUpdateUserCredentialsEmailParams()
@@ -103,18 +122,24 @@ func resourceUserEmailUpdate(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.User.UpdateUserCredentialsEmail(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("send_setup_email") && d.Get("send_setup_email").(bool) {
+		if err := setPasswordResetURL(userID, d, client); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	return resourceUserEmailRead(d, m)
+	return resourceUserEmailRead(ctx, d, m)
 }
 
-func resourceUserEmailDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserEmailDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	userID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := user.NewDeleteUserCredentialsEmailParams()
@@ -122,40 +147,51 @@ func resourceUserEmailDelete(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.User.DeleteUserCredentialsEmail(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceUserEmailExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	client := m.(*apiclient.LookerAPI30Reference)
+// setPasswordResetURL sets password_reset_url to a new link to set the user's password, which is emailed to the user
+// when send_setup_email is set
+func setPasswordResetURL(userID int64, d *schema.ResourceData, client *Client) error {
+	if d.Get("send_setup_email").(bool) {
+		credentials, err := client.User.sendPasswordResetEmail(userID)
+		if err != nil {
+			return fmt.Errorf("Can't send the setup email of user %d: %s", userID, err)
+		}
 
-	userID, err := getIDFromString(d.Id())
-	if err != nil {
-		return false, err
+		d.Set("password_reset_url", credentials.PasswordResetURL)
+		return nil
 	}
 
-	params := user.NewUserCredentialsEmailParams()
+	params := user.NewCreateUserCredentialsEmailPasswordResetParams()
 	params.UserID = userID
 
-	_, err = client.User.UserCredentialsEmail(params)
+	result, err := client.User.CreateUserCredentialsEmailPasswordReset(params)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
-		}
-
-		return false, err
+		return fmt.Errorf("Can't create the password reset link of user %d: %s", userID, err)
 	}
 
-	return true, nil
+	d.Set("password_reset_url", result.Payload.PasswordResetURL)
+	return nil
 }
 
-func resourceUserEmailImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceUserEmailRead(d, m); err != nil {
-		return nil, err
+// resourceUserEmailCustomizeDiff rejects send_setup_email on API 3.0, which can't send the email, and plans a new
+// password_reset_url when the email is sent to existing credentials
+func resourceUserEmailCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("send_setup_email").(bool) {
+		return nil
+	}
+
+	if client, ok := m.(*Client); ok && client != nil && client.APIVersion == apiVersion30 {
+		return fmt.Errorf("send_setup_email requires API %s, the provider is configured for API %s", apiVersion40, client.APIVersion)
+	}
+
+	if d.Id() != "" && d.HasChange("send_setup_email") {
+		return d.SetNewComputed("password_reset_url")
 	}
-	return []*schema.ResourceData{d}, nil
+
+	return nil
 }
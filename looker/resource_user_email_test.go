@@ -0,0 +1,55 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerUserEmail(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(server, "credentials_email", "looker_user_email"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerUserEmailConfig(server, "jane@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("looker_user_email.test", "id", "looker_user.test", "id"),
+					resource.TestCheckResourceAttrSet("looker_user_email.test", "password_reset_url"),
+					testAccCheckRemoteAttr(server, "credentials_email", "looker_user_email.test", "email", "jane@example.com"),
+				),
+			},
+			{
+				Config: testAccLookerUserEmailConfig(server, "jane.doe@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_user_email.test", "email", "jane.doe@example.com"),
+					testAccCheckRemoteAttr(server, "credentials_email", "looker_user_email.test", "email", "jane.doe@example.com"),
+				),
+			},
+			{
+				ResourceName:            "looker_user_email.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"send_setup_email", "password_reset_url"},
+			},
+		},
+	})
+}
+
+func testAccLookerUserEmailConfig(server *lookertest.Server, email string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_user" "test" {
+  first_name = "Jane"
+  last_name  = "Doe"
+}
+
+resource "looker_user_email" "test" {
+  user_id = looker_user.test.id
+  email   = %q
+}
+`, email)
+}
@@ -1,22 +1,21 @@
 package looker
 
 import (
-	"strings"
+	"context"
 
-	apiclient "github.com/billtrust/looker-go-sdk/client"
 	"github.com/billtrust/looker-go-sdk/client/user"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceUserRoles() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserRolesCreate,
-		Read:   resourceUserRolesRead,
-		Update: resourceUserRolesUpdate,
-		Delete: resourceUserRolesDelete,
-		Exists: resourceUserRolesExists,
+		CreateContext: resourceUserRolesCreate,
+		ReadContext:   resourceUserRolesRead,
+		UpdateContext: resourceUserRolesUpdate,
+		DeleteContext: resourceUserRolesDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceUserRolesImport,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -33,14 +32,14 @@ func resourceUserRoles() *schema.Resource {
 	}
 }
 
-func resourceUserRolesCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserRolesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	sUserID := d.Get("user_id").(string)
 
 	iUserID, err := getIDFromString(sUserID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var roleNames []string
@@ -52,7 +51,7 @@ func resourceUserRolesCreate(d *schema.ResourceData, m interface{}) error {
 	roleIds, err := getRoleIds(roleNames, client)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := user.NewSetUserRolesParams()
@@ -61,20 +60,20 @@ func resourceUserRolesCreate(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.User.SetUserRoles(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(sUserID)
 
-	return resourceUserRolesRead(d, m)
+	return resourceUserRolesRead(ctx, d, m)
 }
 
-func resourceUserRolesRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*apiclient.LookerAPI30Reference)
+func resourceUserRolesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
 
 	userID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := user.NewUserRolesParams()
@@ -82,11 +81,11 @@ func resourceUserRolesRead(d *schema.ResourceData, m interface{}) error {
 
 	rolesResult, err := client.User.UserRoles(params)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	roleNames := []string{}
@@ -94,24 +93,24 @@ func resourceUserRolesRead(d *schema.ResourceData, m interface{}) error {
 		roleNames = append(roleNames, role.Name)
 	}
 
-	d.Set("user_id", userID)
+	d.Set("user_id", d.Id())
 	d.Set("role_names", roleNames)
 
 	return nil
 }
 
-func resourceUserRolesUpdate(d *schema.ResourceData, m interface{}) error {
+func resourceUserRolesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// TODO: There is no functional difference between "Creating" and "updating" which roles a user has.  Only settings the roles currently assigned to the user. Is this the correct implemenation in thise case?
-	return resourceUserRolesCreate(d, m)
+	return resourceUserRolesCreate(ctx, d, m)
 }
 
-func resourceUserRolesDelete(d *schema.ResourceData, m interface{}) error {
+func resourceUserRolesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// TODO: Delete really just removes all the roles from the user.  Is this the correct way to implement delete in this case?
-	client := m.(*apiclient.LookerAPI30Reference)
+	client := m.(*Client)
 
 	userID, err := getIDFromString(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	params := user.NewSetUserRolesParams()
@@ -120,41 +119,8 @@ func resourceUserRolesDelete(d *schema.ResourceData, m interface{}) error {
 
 	_, err = client.User.SetUserRoles(params)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
-
-func resourceUserRolesExists(d *schema.ResourceData, m interface{}) (b bool, e error) {
-	// Exists - This is called to verify a resource still exists. It is called prior to Read,
-	// and lowers the burden of Read to be able to assume the resource exists.
-	client := m.(*apiclient.LookerAPI30Reference)
-
-	userID, err := getIDFromString(d.Id())
-	if err != nil {
-		return false, err
-	}
-
-	// TODO: as long as the user exists, we will say the "roles" exist. Not sure if this is correct though?
-	params := user.NewUserParams()
-	params.UserID = userID
-
-	_, err = client.User.User(params)
-	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
-			return false, nil
-		}
-
-		return false, err
-	}
-
-	return true, nil
-}
-
-func resourceUserRolesImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	if err := resourceUserRolesRead(d, m); err != nil {
-		return nil, err
-	}
-	return []*schema.ResourceData{d}, nil
-}
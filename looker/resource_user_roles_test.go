@@ -0,0 +1,72 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerUserRoles(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerUserRolesConfig(server, `["Admin"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("looker_user_roles.test", "id", "looker_user.test", "id"),
+					resource.TestCheckResourceAttr("looker_user_roles.test", "role_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr("looker_user_roles.test", "role_names.*", "Admin"),
+				),
+			},
+			{
+				Config: testAccLookerUserRolesConfig(server, `["Admin", looker_role.analyst.name]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_user_roles.test", "role_names.#", "2"),
+					resource.TestCheckTypeSetElemAttr("looker_user_roles.test", "role_names.*", "Analyst"),
+				),
+			},
+			{
+				ResourceName:      "looker_user_roles.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// destroying the resource removes every role of the user
+				Config: testAccLookerUserRolesConfig(server, ""),
+				Check:  testAccCheckRemoteAttr(server, "users", "looker_user.test", "role_ids", "[]"),
+			},
+		},
+	})
+}
+
+func testAccLookerUserRolesConfig(server *lookertest.Server, roleNames string) string {
+	config := server.ProviderConfig() + `
+data "looker_role" "admin" {
+  name = "Admin"
+}
+
+resource "looker_role" "analyst" {
+  name              = "Analyst"
+  permission_set_id = data.looker_role.admin.permission_set_id
+  model_set_id      = data.looker_role.admin.model_set_id
+}
+
+resource "looker_user" "test" {
+  first_name = "Jane"
+  last_name  = "Doe"
+}
+`
+	if roleNames != "" {
+		config += fmt.Sprintf(`
+resource "looker_user_roles" "test" {
+  user_id    = looker_user.test.id
+  role_names = %s
+}
+`, roleNames)
+	}
+	return config
+}
@@ -0,0 +1,50 @@
+package looker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/billtrust/terraform-provider-looker/looker/lookertest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccLookerUser(t *testing.T) {
+	server := testAccServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(server, "users", "looker_user"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLookerUserConfig(server, "Doe"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(server, "users", "looker_user.test"),
+					resource.TestCheckResourceAttr("looker_user.test", "first_name", "Jane"),
+					resource.TestCheckResourceAttr("looker_user.test", "last_name", "Doe"),
+				),
+			},
+			{
+				Config: testAccLookerUserConfig(server, "Smith"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_user.test", "last_name", "Smith"),
+					testAccCheckRemoteAttr(server, "users", "looker_user.test", "last_name", "Smith"),
+				),
+			},
+			{
+				ResourceName:            "looker_user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deactivate_instead_of_delete"},
+			},
+		},
+	})
+}
+
+func testAccLookerUserConfig(server *lookertest.Server, lastName string) string {
+	return server.ProviderConfig() + fmt.Sprintf(`
+resource "looker_user" "test" {
+  first_name = "Jane"
+  last_name  = %q
+}
+`, lastName)
+}
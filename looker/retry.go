@@ -0,0 +1,160 @@
+package looker
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// retryTransport is an http.RoundTripper that retries requests failing with a transient error. Requests are only
+// retried when doing so cannot apply a change twice: idempotent requests are retried on 429, 502-504 and connection
+// resets, any request is retried when the server or the network rejected it before it was processed.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, minBackoff time.Duration, maxBackoff time.Duration) *retryTransport {
+	if maxBackoff < minBackoff {
+		maxBackoff = minBackoff
+	}
+
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the body has to be read up front so it can be sent again on every attempt
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+
+	for attempt := 0; ; attempt++ {
+		r := req.Clone(req.Context())
+		if body != nil {
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+			r.GetBody = func() (io.ReadCloser, error) {
+				return ioutil.NopCloser(bytes.NewReader(body)), nil
+			}
+		}
+
+		resp, err := t.next.RoundTrip(r)
+		if attempt >= t.maxRetries || !shouldRetry(req.Method, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if err != nil {
+			log.Printf("[WARN] %s %s failed with %s, retrying in %s", req.Method, req.URL.Path, err.Error(), wait)
+		} else {
+			log.Printf("[WARN] %s %s failed with status %d, retrying in %s", req.Method, req.URL.Path, resp.StatusCode, wait)
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns how long to wait before the given retry, preferring the server's Retry-After header, up to maxBackoff
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			// a server asking for a longer wait than max_backoff shouldn't stall the run
+			if wait > t.maxBackoff {
+				wait = t.maxBackoff
+			}
+			return wait
+		}
+	}
+
+	wait := t.minBackoff
+	for i := 0; i < attempt && wait < t.maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > t.maxBackoff {
+		wait = t.maxBackoff
+	}
+
+	// jitter between half and the full backoff so parallel resources don't retry in lockstep
+	if half := int64(wait / 2); half > 0 {
+		wait = time.Duration(half + rand.Int63n(half+1))
+	}
+
+	return wait
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if err != nil {
+		// a refused connection never reached the server
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return true
+		}
+		return isIdempotent(method) && (errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF))
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		// the request was rejected without being processed
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	// PATCH isn't idempotent by definition, so one that may already have been applied is not sent again
+	return false
+}
@@ -0,0 +1,75 @@
+package looker
+
+import (
+	"io"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := newRetryTransport(http.DefaultTransport, 3, time.Second, 30*time.Second)
+
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		min        time.Duration
+		max        time.Duration
+	}{
+		{"first retry", 0, "", 500 * time.Millisecond, time.Second},
+		{"third retry", 2, "", 2 * time.Second, 4 * time.Second},
+		{"capped", 10, "", 15 * time.Second, 30 * time.Second},
+		{"retry after", 0, "7", 7 * time.Second, 7 * time.Second},
+		{"retry after over the maximum", 0, "3600", 30 * time.Second, 30 * time.Second},
+		{"retry after date over the maximum", 0, time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), 30 * time.Second, 30 * time.Second},
+		{"invalid retry after", 0, "soon", 500 * time.Millisecond, time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+			if test.retryAfter != "" {
+				resp.Header.Set("Retry-After", test.retryAfter)
+			}
+
+			wait := transport.backoff(test.attempt, resp)
+			if wait < test.min || wait > test.max {
+				t.Errorf("backoff(%d) = %s, want between %s and %s", test.attempt, wait, test.min, test.max)
+			}
+		})
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	tests := []struct {
+		method string
+		status int
+		err    error
+		want   bool
+	}{
+		{http.MethodGet, http.StatusBadGateway, nil, true},
+		{http.MethodPut, http.StatusGatewayTimeout, nil, true},
+		{http.MethodPatch, http.StatusGatewayTimeout, nil, false},
+		{http.MethodPost, http.StatusGatewayTimeout, nil, false},
+		{http.MethodPost, http.StatusTooManyRequests, nil, true},
+		{http.MethodPatch, http.StatusServiceUnavailable, nil, true},
+		{http.MethodGet, http.StatusInternalServerError, nil, false},
+		{http.MethodDelete, 0, syscall.ECONNRESET, true},
+		{http.MethodPatch, 0, syscall.ECONNRESET, false},
+		{http.MethodPatch, 0, io.ErrUnexpectedEOF, false},
+		{http.MethodPost, 0, syscall.ECONNREFUSED, true},
+	}
+
+	for _, test := range tests {
+		var resp *http.Response
+		if test.err == nil {
+			resp = &http.Response{StatusCode: test.status}
+		}
+
+		if got := shouldRetry(test.method, resp, test.err); got != test.want {
+			t.Errorf("shouldRetry(%s, %d, %v) = %t, want %t", test.method, test.status, test.err, got, test.want)
+		}
+	}
+}
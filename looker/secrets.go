@@ -0,0 +1,70 @@
+package looker
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Secrets the API accepts but never returns, like connection passwords, are kept in the state as a salted hash of
+// the value last applied. A configured value is compared with the hash, so changing it plans an update while the
+// value itself is never written to the state.
+
+const secretHashPrefix = "salted-sha256:"
+
+// hashSecret returns the salted hash of value to store in the state
+func hashSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		panic(err)
+	}
+
+	return secretHashPrefix + hex.EncodeToString(salt) + ":" + saltedHash(salt, value)
+}
+
+func saltedHash(salt []byte, value string) string {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(value))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// secretMatches reports whether value is the secret stored in the state. States written before secrets were hashed
+// hold the value itself.
+func secretMatches(value string, stored string) bool {
+	if !strings.HasPrefix(stored, secretHashPrefix) {
+		return value == stored
+	}
+
+	parts := strings.Split(strings.TrimPrefix(stored, secretHashPrefix), ":")
+	if len(parts) != 2 {
+		return false
+	}
+
+	salt, err := hex.DecodeString(parts[0])
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(saltedHash(salt, value)), []byte(parts[1])) == 1
+}
+
+// suppressSecretDiff is the DiffSuppressFunc of hashed secrets
+func suppressSecretDiff(k, old, new string, d *schema.ResourceData) bool {
+	return secretMatches(new, old)
+}
+
+// setSecretHashes replaces the secrets just applied with their hashes
+func setSecretHashes(d *schema.ResourceData, keys ...string) {
+	for _, key := range keys {
+		d.Set(key, hashSecret(d.Get(key).(string)))
+	}
+}
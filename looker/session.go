@@ -0,0 +1,89 @@
+package looker
+
+import (
+	"sync"
+
+	"github.com/billtrust/looker-go-sdk/client/session"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+const (
+	workspaceProduction = "production"
+	workspaceDev        = "dev"
+)
+
+// sessionManager serializes operations that depend on the API session's workspace. Looker keeps one workspace per
+// access token and the provider shares its token between all resources, which terraform runs in parallel. Without
+// this a resource could switch to production while another is in the middle of its dev mode calls.
+type sessionManager struct {
+	session sessionAPI
+
+	// held while an operation runs in a workspace other than production
+	mu sync.Mutex
+
+	workspaceMu sync.Mutex
+	workspace   string
+}
+
+func newSessionManager(session sessionAPI) *sessionManager {
+	return &sessionManager{
+		session:   session,
+		workspace: workspaceProduction,
+	}
+}
+
+// withWorkspace switches the session to workspace, runs fn and switches the session back to production
+func (s *sessionManager) withWorkspace(workspace string, fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := updateWorkspace(s.session, workspace); err != nil {
+		return err
+	}
+	s.setWorkspace(workspace)
+
+	err := fn()
+
+	s.setWorkspace(workspaceProduction)
+	if restoreErr := updateWorkspace(s.session, workspaceProduction); restoreErr != nil && err == nil {
+		err = restoreErr
+	}
+
+	return err
+}
+
+// afterLogin is called by authTransport with every new access token. A new token starts a new session in
+// production, so the workspace of a running operation has to be selected again.
+func (s *sessionManager) afterLogin(transport runtime.ClientTransport) error {
+	s.workspaceMu.Lock()
+	workspace := s.workspace
+	s.workspaceMu.Unlock()
+
+	if workspace == workspaceProduction {
+		return nil
+	}
+
+	return updateWorkspace(session.New(transport, strfmt.Default), workspace)
+}
+
+func (s *sessionManager) setWorkspace(workspace string) {
+	s.workspaceMu.Lock()
+	s.workspace = workspace
+	s.workspaceMu.Unlock()
+}
+
+func updateWorkspace(client sessionAPI, workspace string) error {
+	params := session.NewUpdateSessionParams()
+	params.Body = &models.APISession{}
+	params.Body.WorkspaceID = workspace
+
+	_, err := client.UpdateSession(params)
+	return err
+}
+
+// withWorkspace runs fn with the API session switched to workspace. Only one such operation runs at a time.
+func (c *Client) withWorkspace(workspace string, fn func() error) error {
+	return c.sessions.withWorkspace(workspace, fn)
+}
@@ -0,0 +1,79 @@
+package looker
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/billtrust/looker-go-sdk/client/session"
+)
+
+// TestWithWorkspace runs workspace operations in parallel, as terraform runs resources, while the access tokens
+// expire, and checks every operation sees its workspace from start to end. Run it with -race.
+func TestWithWorkspace(t *testing.T) {
+	server := testAccServer(t)
+	client := newTestClient(t, server)
+	sessions := client.Session.(*session.Client)
+
+	currentWorkspace := func() (string, error) {
+		result, err := sessions.Session(session.NewSessionParams())
+		if err != nil {
+			return "", err
+		}
+		return result.Payload.WorkspaceID, nil
+	}
+
+	const workers = 8
+	const operations = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*operations)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			for j := 0; j < operations; j++ {
+				workspace := workspaceDev
+				if (worker+j)%3 == 0 {
+					workspace = workspaceProduction
+				}
+
+				err := client.withWorkspace(workspace, func() error {
+					for k := 0; k < 3; k++ {
+						if worker == 0 && k == 1 {
+							// the next request logs in again, starting a new session in production
+							server.ExpireTokens()
+						}
+
+						got, err := currentWorkspace()
+						if err != nil {
+							return err
+						}
+						if got != workspace {
+							return fmt.Errorf("worker %d operation %d: the session switched to %s during a call in %s", worker, j, got, workspace)
+						}
+					}
+					return nil
+				})
+				if err != nil {
+					errs <- err
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	workspace, err := currentWorkspace()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workspace != workspaceProduction {
+		t.Errorf("the session was left in %s", workspace)
+	}
+}
@@ -0,0 +1,80 @@
+package looker
+
+import (
+	"github.com/billtrust/looker-go-sdk/client/user_attribute"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// userAttributeClient sends the flags of user attributes even when they are false. The SDK model omits false
+// booleans, which would leave them at their previous value on PATCH, so a flag could never be turned off.
+type userAttributeClient struct {
+	*user_attribute.Client
+	transport runtime.ClientTransport
+}
+
+// userAttributeBody shadows the flags of models.UserAttribute with fields that are always encoded
+type userAttributeBody struct {
+	*models.UserAttribute
+	UserCanEdit   bool `json:"user_can_edit"`
+	UserCanView   bool `json:"user_can_view"`
+	ValueIsHidden bool `json:"value_is_hidden"`
+}
+
+func newUserAttributeBody(attribute *models.UserAttribute) *userAttributeBody {
+	return &userAttributeBody{
+		UserAttribute: attribute,
+		UserCanEdit:   attribute.UserCanEdit,
+		UserCanView:   attribute.UserCanView,
+		ValueIsHidden: attribute.ValueIsHidden,
+	}
+}
+
+// CreateUserAttribute implements userAttributeAPI
+func (c *userAttributeClient) CreateUserAttribute(params *user_attribute.CreateUserAttributeParams) (*user_attribute.CreateUserAttributeOK, error) {
+	result, err := c.transport.Submit(&runtime.ClientOperation{
+		ID:                 "create_user_attribute",
+		Method:             "POST",
+		PathPattern:        "/user_attributes",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			return r.SetBodyParam(newUserAttributeBody(params.Body))
+		}),
+		Reader:  &user_attribute.CreateUserAttributeReader{},
+		Context: params.Context,
+		Client:  params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*user_attribute.CreateUserAttributeOK), nil
+}
+
+// UpdateUserAttribute implements userAttributeAPI
+func (c *userAttributeClient) UpdateUserAttribute(params *user_attribute.UpdateUserAttributeParams) (*user_attribute.UpdateUserAttributeOK, error) {
+	result, err := c.transport.Submit(&runtime.ClientOperation{
+		ID:                 "update_user_attribute",
+		Method:             "PATCH",
+		PathPattern:        "/user_attributes/{user_attribute_id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := r.SetPathParam("user_attribute_id", swag.FormatInt64(params.UserAttributeID)); err != nil {
+				return err
+			}
+			return r.SetBodyParam(newUserAttributeBody(params.Body))
+		}),
+		Reader:  &user_attribute.UpdateUserAttributeReader{},
+		Context: params.Context,
+		Client:  params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*user_attribute.UpdateUserAttributeOK), nil
+}
@@ -0,0 +1,156 @@
+package looker
+
+import (
+	"net/http"
+
+	"github.com/billtrust/looker-go-sdk/client/user"
+	"github.com/billtrust/looker-go-sdk/models"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// lookerUser is a user as the API returns it, with the fields models.User lacks
+type lookerUser struct {
+	*models.User
+	UIState map[string]string `json:"ui_state,omitempty"`
+}
+
+// userBody are the writable fields of a user. Unlike models.User, false booleans are sent when set, so a user can
+// be enabled again, and fields left empty are not sent, so they are left to whatever else manages them.
+type userBody struct {
+	FirstName          string            `json:"first_name,omitempty"`
+	LastName           string            `json:"last_name,omitempty"`
+	IsDisabled         *bool             `json:"is_disabled,omitempty"`
+	Locale             string            `json:"locale,omitempty"`
+	HomeSpaceID        string            `json:"home_space_id,omitempty"`
+	ModelsDirValidated *bool             `json:"models_dir_validated,omitempty"`
+	UIState            map[string]string `json:"ui_state,omitempty"`
+}
+
+// credentialsAPI3 are API credentials as they are created, with the secret models.CredentialsApi3 lacks. The API
+// only returns the secret then.
+type credentialsAPI3 struct {
+	*models.CredentialsApi3
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// userClient reads and writes users as lookerUser and userBody, and leaves the other operations to the SDK
+type userClient struct {
+	*user.Client
+	transport runtime.ClientTransport
+}
+
+func (c *userClient) getUser(userID int64) (*lookerUser, error) {
+	return c.submit("user", "GET", "/users/{user_id}", userID, nil, &user.UserReader{})
+}
+
+func (c *userClient) createUser(body *userBody) (*lookerUser, error) {
+	return c.submit("create_user", "POST", "/users", 0, body, &user.CreateUserReader{})
+}
+
+func (c *userClient) updateUser(userID int64, body *userBody) (*lookerUser, error) {
+	return c.submit("update_user", "PATCH", "/users/{user_id}", userID, body, &user.UpdateUserReader{})
+}
+
+// submit runs a user operation, decoding errors with the SDK's reader so they keep their types
+func (c *userClient) submit(id string, method string, path string, userID int64, body *userBody, errorReader runtime.ClientResponseReader) (*lookerUser, error) {
+	result, err := c.transport.Submit(&runtime.ClientOperation{
+		ID:                 id,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if userID != 0 {
+				if err := r.SetPathParam("user_id", swag.FormatInt64(userID)); err != nil {
+					return err
+				}
+			}
+			if body != nil {
+				return r.SetBodyParam(body)
+			}
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() != http.StatusOK {
+				return errorReader.ReadResponse(response, consumer)
+			}
+
+			result := &lookerUser{User: &models.User{}}
+			if err := consumer.Consume(response.Body(), result); err != nil {
+				return nil, err
+			}
+			return result, nil
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*lookerUser), nil
+}
+
+// sendPasswordResetEmail emails the user a link to set their password, which is also the way to finish the setup of
+// new email credentials. Only API 4.0 has this operation.
+func (c *userClient) sendPasswordResetEmail(userID int64) (*models.CredentialsEmail, error) {
+	result, err := c.transport.Submit(&runtime.ClientOperation{
+		ID:                 "send_user_credentials_email_password_reset",
+		Method:             "POST",
+		PathPattern:        "/users/{user_id}/credentials_email/send_password_reset",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			return r.SetPathParam("user_id", swag.FormatInt64(userID))
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() != http.StatusOK {
+				return (&user.CreateUserCredentialsEmailPasswordResetReader{}).ReadResponse(response, consumer)
+			}
+
+			result := &models.CredentialsEmail{}
+			if err := consumer.Consume(response.Body(), result); err != nil {
+				return nil, err
+			}
+			return result, nil
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*models.CredentialsEmail), nil
+}
+
+// createCredentialsAPI3 creates API credentials for the user, returning their secret
+func (c *userClient) createCredentialsAPI3(userID int64) (*credentialsAPI3, error) {
+	result, err := c.transport.Submit(&runtime.ClientOperation{
+		ID:                 "create_user_credentials_api3",
+		Method:             "POST",
+		PathPattern:        "/users/{user_id}/credentials_api3",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := r.SetPathParam("user_id", swag.FormatInt64(userID)); err != nil {
+				return err
+			}
+			return r.SetBodyParam(&models.CredentialsApi3{})
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() != http.StatusOK {
+				return (&user.CreateUserCredentialsApi3Reader{}).ReadResponse(response, consumer)
+			}
+
+			result := &credentialsAPI3{CredentialsApi3: &models.CredentialsApi3{}}
+			if err := consumer.Consume(response.Body(), result); err != nil {
+				return nil, err
+			}
+			return result, nil
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*credentialsAPI3), nil
+}
@@ -0,0 +1,100 @@
+package looker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateOneOf returns a validation function accepting only the given values, which suggests the closest ones
+// when it fails. kind names the values in the error, e.g. "user attribute type".
+func validateOneOf(kind string, values []string) schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		value := v.(string)
+		for _, valid := range values {
+			if value == valid {
+				return nil
+			}
+		}
+
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("%q is not a %s%s", value, kind, didYouMean(value, values)),
+			Detail:        fmt.Sprintf("Valid values are %s", strings.Join(values, ", ")),
+			AttributePath: path,
+		}}
+	}
+}
+
+// didYouMean returns a suggestion of the candidates closest to value, or nothing if none of them is close
+func didYouMean(value string, candidates []string) string {
+	matches := closeMatches(value, candidates)
+	if len(matches) == 0 {
+		return ""
+	}
+
+	quoted := []string{}
+	for _, match := range matches {
+		quoted = append(quoted, fmt.Sprintf("%q", match))
+	}
+
+	return ", did you mean " + strings.Join(quoted, " or ") + "?"
+}
+
+// closeMatches returns up to three of the candidates closest to value, if they are within a few edits of it
+func closeMatches(value string, candidates []string) []string {
+	type match struct {
+		candidate string
+		distance  int
+	}
+
+	maxDistance := len(value) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	matches := []match{}
+	for _, candidate := range candidates {
+		if distance := editDistance(value, candidate); distance <= maxDistance {
+			matches = append(matches, match{candidate, distance})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	result := []string{}
+	for i := 0; i < len(matches) && i < 3 && matches[i].distance == matches[0].distance; i++ {
+		result = append(result, matches[i].candidate)
+	}
+
+	return result
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
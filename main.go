@@ -2,11 +2,13 @@ package main
 
 import (
 	"github.com/billtrust/terraform-provider-looker/looker"
-	"github.com/hashicorp/terraform/plugin"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
 
 func main() {
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: looker.Provider,
 	})
+
+	looker.Logout()
 }